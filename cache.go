@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/url"
+	"sync"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// responseCacheMax bounds the cache since query parameters like count
+// can take any value, the cache just starts over once it's full
+const responseCacheMax = 1024
+
+type cachedResponse struct {
+	stamp       int64
+	contentType string
+	body        []byte
+}
+
+type responseCache struct {
+	mu      sync.RWMutex
+	entries map[string]cachedResponse
+}
+
+var responses = &responseCache{
+	entries: make(map[string]cachedResponse),
+}
+
+func (rc *responseCache) get(key string, stamp int64) (cachedResponse, bool) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	entry, ok := rc.entries[key]
+	if !ok || entry.stamp != stamp {
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (rc *responseCache) set(key string, entry cachedResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if _, ok := rc.entries[key]; !ok && len(rc.entries) >= responseCacheMax {
+		rc.entries = make(map[string]cachedResponse)
+	}
+	rc.entries[key] = entry
+}
+
+// stampCache serves repeated requests from memory for as long as the stamp
+// returned by stampFn stays the same, only the listed query parameters
+// are part of the cache key
+func stampCache(stampFn func() int64, params ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		query := url.Values{}
+		for _, param := range params {
+			if value := c.Query(param); value != "" {
+				query.Set(param, value)
+			}
+		}
+		key := c.Path() + "?" + query.Encode()
+
+		// the stamp is read before the handler runs, so a response built
+		// while the stamp moves is stored under the older stamp
+		stamp := stampFn()
+		if entry, ok := responses.get(key, stamp); ok {
+			c.Set("X-Cache", "HIT")
+			c.Set(fiber.HeaderContentType, entry.contentType)
+			return c.Send(entry.body)
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if c.Response().StatusCode() == fiber.StatusOK {
+			responses.set(key, cachedResponse{
+				stamp:       stamp,
+				contentType: string(c.Response().Header.ContentType()),
+				body:        append([]byte(nil), c.Response().Body()...),
+			})
+		}
+		c.Set("X-Cache", "MISS")
+		return nil
+	}
+}
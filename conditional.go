@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// conditional adds ETag and Last-Modified validators derived from modified
+// and answers matching If-None-Match / If-Modified-Since requests with a 304,
+// the response varies by query too but validators only ever get compared
+// against the same URL so the query doesn't need to be part of the ETag
+func conditional(modified func() (time.Time, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lastModified, err := modified()
+		if err != nil || lastModified.IsZero() {
+			return c.Next()
+		}
+
+		etag := `W/"` + strconv.FormatInt(lastModified.UnixMilli(), 16) + `"`
+		c.Set(fiber.HeaderETag, etag)
+		c.Set(fiber.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+		// make browsers and the CDN revalidate instead of guessing a freshness
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+
+		if notModified(c, etag, lastModified) {
+			c.Status(fiber.StatusNotModified)
+			return nil
+		}
+
+		return c.Next()
+	}
+}
+
+// notModified follows RFC 9110, If-Modified-Since
+// is ignored whenever If-None-Match is present
+func notModified(c *fiber.Ctx, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := c.Get(fiber.HeaderIfModifiedSince); ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// stampModified turns a millisecond stamp into a validator source
+func stampModified(stampFn func() int64) func() (time.Time, error) {
+	return func() (time.Time, error) {
+		stamp := stampFn()
+		if stamp == 0 {
+			return time.Time{}, nil
+		}
+		return time.UnixMilli(stamp), nil
+	}
+}
+
+// processStart is the oldest validator /nukes can have, its output ages
+// with time and the expiries that move the stamp when a nuke drops out only
+// live in memory, so one that aged out while the process was down would
+// otherwise keep a client's old copy valid
+var processStart = time.Now()
+
+// nukesModified is the nukes stamp, which scheduleNukeExpiries also moves
+// whenever a nuke drops out of the nukesWindow, bounded by processStart
+func nukesModified() (time.Time, error) {
+	modified := time.UnixMilli(stamps.get(stampNukes))
+	if modified.Before(processStart) {
+		return processStart, nil
+	}
+	return modified, nil
+}
+
+// latestStamp is the newest of all the stamps /nmptimestamps returns
+func latestStamp() int64 {
+	latest := stamps.phrases()
+	for _, name := range []string{stampNukes, stampMutelinks, stampEmbeds} {
+		if stamp := stamps.get(name); stamp > latest {
+			latest = stamp
+		}
+	}
+	return latest
+}
+
+// fileModified uses the mtime of an SQLite database, the WAL file
+// is checked as well since writes land there before a checkpoint
+func fileModified(path string) func() (time.Time, error) {
+	return func() (time.Time, error) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		latest := info.ModTime()
+		if wal, err := os.Stat(path + "-wal"); err == nil && wal.ModTime().After(latest) {
+			latest = wal.ModTime()
+		}
+		return latest, nil
+	}
+}
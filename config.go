@@ -0,0 +1,457 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/joho/godotenv"
+	log "github.com/vyneer/vyneer-api/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// config is everything the server can be configured with, the sources are
+// applied from the lowest to the highest precedence: the defaults, the config
+// file, .env, the environment variables and finally the command line flags,
+// only the settings in reloadable can change while the server runs
+type config struct {
+	Port           string   `yaml:"port"`
+	APIPrefix      string   `yaml:"apiPrefix"`
+	TrustedProxies []string `yaml:"trustedProxies"`
+	AdminToken     string   `yaml:"adminToken"`
+	LogLevel       string   `yaml:"logLevel"`
+	LogFormat      string   `yaml:"logFormat"`
+
+	Postgres struct {
+		User     string `yaml:"user"`
+		Password string `yaml:"password"`
+		Host     string `yaml:"host"`
+		Port     string `yaml:"port"`
+		DB       string `yaml:"db"`
+	} `yaml:"postgres"`
+
+	Redis struct {
+		Host     string `yaml:"host"`
+		Port     string `yaml:"port"`
+		Password string `yaml:"password"`
+	} `yaml:"redis"`
+
+	GRPC struct {
+		Port                 string `yaml:"port"`
+		Token                string `yaml:"token"`
+		AllowUnauthenticated bool   `yaml:"allowUnauthenticated"`
+		TLSCert              string `yaml:"tlsCert"`
+		TLSKey               string `yaml:"tlsKey"`
+		TLSClientCA          string `yaml:"tlsClientCA"`
+	} `yaml:"grpc"`
+
+	SQLite struct {
+		Features   string `yaml:"features"`
+		LWOD       string `yaml:"lwod"`
+		YTVods     string `yaml:"ytvods"`
+		Rumble     string `yaml:"rumble"`
+		Omnimirror string `yaml:"omnimirror"`
+		Embeds     string `yaml:"embeds"`
+	} `yaml:"sqlite"`
+
+	RateLimit struct {
+		Max        int           `yaml:"max"`
+		Expiration time.Duration `yaml:"expiration"`
+	} `yaml:"rateLimit"`
+
+	CORS struct {
+		AllowOrigins     string `yaml:"allowOrigins"`
+		AllowMethods     string `yaml:"allowMethods"`
+		AllowHeaders     string `yaml:"allowHeaders"`
+		AllowCredentials bool   `yaml:"allowCredentials"`
+		MaxAge           int    `yaml:"maxAge"`
+	} `yaml:"cors"`
+
+	Toggles struct {
+		Events     bool `yaml:"events"`
+		LiveLogs   bool `yaml:"liveLogs"`
+		WebSockets bool `yaml:"webSockets"`
+		GRPCWeb    bool `yaml:"grpcWeb"`
+		Webhooks   bool `yaml:"webhooks"`
+	} `yaml:"toggles"`
+
+	PersistStamps      bool          `yaml:"persistStamps"`
+	EventDedupeWindow  time.Duration `yaml:"eventDedupeWindow"`
+	ShutdownTimeout    time.Duration `yaml:"shutdownTimeout"`
+	ShutdownDrainDelay time.Duration `yaml:"shutdownDrainDelay"`
+
+	LogsCache struct {
+		TTL      time.Duration `yaml:"ttl"`
+		MaxBytes int           `yaml:"maxBytes"`
+	} `yaml:"logsCache"`
+}
+
+var cfg = defaultConfig()
+
+func defaultConfig() config {
+	c := config{}
+	c.LogLevel = "info"
+	c.LogFormat = "text"
+	c.GRPC.Port = "6413"
+	c.SQLite.Features = filepath.Join(".", "db", "featdb.db")
+	c.SQLite.LWOD = filepath.Join(".", "db", "lwoddb.db")
+	c.SQLite.YTVods = filepath.Join(".", "db", "ytvoddb.db")
+	c.SQLite.Rumble = filepath.Join(".", "db", "rumble.sqlite")
+	c.SQLite.Omnimirror = filepath.Join(".", "db", "omnimirror.sqlite")
+	c.SQLite.Embeds = filepath.Join(".", "db", "embeddb.db")
+	c.RateLimit.Max = 60
+	c.RateLimit.Expiration = time.Minute
+	c.CORS.AllowOrigins = "*"
+	c.CORS.AllowMethods = "GET,POST,HEAD,PUT,DELETE,PATCH"
+	c.Toggles.Events = true
+	c.Toggles.LiveLogs = true
+	c.Toggles.WebSockets = true
+	c.Toggles.GRPCWeb = true
+	c.Toggles.Webhooks = true
+	c.EventDedupeWindow = time.Minute * 10
+	c.ShutdownTimeout = time.Second * 15
+	c.LogsCache.TTL = time.Hour * 24
+	c.LogsCache.MaxBytes = 4 << 20
+	return c
+}
+
+// setting ties an environment variable to a config field, the flag
+// name is derived from it, so POSTGRES_USER becomes --postgres-user
+type setting struct {
+	env   string
+	usage string
+	apply func(c *config, value string) error
+}
+
+func (s setting) flagName() string {
+	return strings.ToLower(strings.ReplaceAll(s.env, "_", "-"))
+}
+
+func stringSetting(env string, usage string, field func(c *config) *string) setting {
+	return setting{env, usage, func(c *config, value string) error {
+		*field(c) = value
+		return nil
+	}}
+}
+
+func listSetting(env string, usage string, field func(c *config) *[]string) setting {
+	return setting{env, usage, func(c *config, value string) error {
+		list := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		*field(c) = list
+		return nil
+	}}
+}
+
+func intSetting(env string, usage string, field func(c *config) *int) setting {
+	return setting{env, usage, func(c *config, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q isn't a valid number", value)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func boolSetting(env string, usage string, field func(c *config) *bool) setting {
+	return setting{env, usage, func(c *config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q isn't a valid boolean", value)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+func durationSetting(env string, usage string, field func(c *config) *time.Duration) setting {
+	return setting{env, usage, func(c *config, value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q isn't a valid duration (like 10m)", value)
+		}
+		*field(c) = parsed
+		return nil
+	}}
+}
+
+var settings = []setting{
+	stringSetting("PORT", "HTTP port", func(c *config) *string { return &c.Port }),
+	stringSetting("API_PREFIX", "prefix for every HTTP route", func(c *config) *string { return &c.APIPrefix }),
+	listSetting("TRUSTED_PROXY", "comma-separated trusted proxy IPs", func(c *config) *[]string { return &c.TrustedProxies }),
+	stringSetting("ADMIN_TOKEN", "bearer token for the admin endpoints, they're disabled without one", func(c *config) *string { return &c.AdminToken }),
+	stringSetting("LOG_LEVEL", "debug, info, warn, error or fatal", func(c *config) *string { return &c.LogLevel }),
+	stringSetting("LOG_FORMAT", "text or json", func(c *config) *string { return &c.LogFormat }),
+	stringSetting("POSTGRES_USER", "Postgres user", func(c *config) *string { return &c.Postgres.User }),
+	stringSetting("POSTGRES_PASSWORD", "Postgres password", func(c *config) *string { return &c.Postgres.Password }),
+	stringSetting("POSTGRES_HOST", "Postgres host, leave empty to disable", func(c *config) *string { return &c.Postgres.Host }),
+	stringSetting("POSTGRES_PORT", "Postgres port", func(c *config) *string { return &c.Postgres.Port }),
+	stringSetting("POSTGRES_DB", "Postgres database", func(c *config) *string { return &c.Postgres.DB }),
+	stringSetting("REDIS_HOST", "Redis host, leave empty to disable", func(c *config) *string { return &c.Redis.Host }),
+	stringSetting("REDIS_PORT", "Redis port", func(c *config) *string { return &c.Redis.Port }),
+	stringSetting("REDIS_PASSWORD", "Redis password", func(c *config) *string { return &c.Redis.Password }),
+	stringSetting("GRPC_PORT", "gRPC port", func(c *config) *string { return &c.GRPC.Port }),
+	stringSetting("GRPC_TOKEN", "bearer token for the gRPC ingestion RPCs", func(c *config) *string { return &c.GRPC.Token }),
+	boolSetting("GRPC_ALLOW_UNAUTHENTICATED", "accept unauthenticated gRPC ingestion RPCs when there's no GRPC_TOKEN", func(c *config) *bool { return &c.GRPC.AllowUnauthenticated }),
+	stringSetting("GRPC_TLS_CERT", "gRPC TLS certificate file", func(c *config) *string { return &c.GRPC.TLSCert }),
+	stringSetting("GRPC_TLS_KEY", "gRPC TLS key file", func(c *config) *string { return &c.GRPC.TLSKey }),
+	stringSetting("GRPC_TLS_CLIENT_CA", "CA file for gRPC client certificates, enables mTLS", func(c *config) *string { return &c.GRPC.TLSClientCA }),
+	stringSetting("FEATDB_PATH", "features SQLite database, off to disable", func(c *config) *string { return &c.SQLite.Features }),
+	stringSetting("LWODDB_PATH", "LWOD SQLite database, off to disable", func(c *config) *string { return &c.SQLite.LWOD }),
+	stringSetting("YTVODDB_PATH", "YouTube VODs SQLite database, off to disable", func(c *config) *string { return &c.SQLite.YTVods }),
+	stringSetting("RUMBLEDB_PATH", "Rumble VODs SQLite database, off to disable", func(c *config) *string { return &c.SQLite.Rumble }),
+	stringSetting("OMNIMIRRORDB_PATH", "Omnimirror VODs SQLite database, off to disable", func(c *config) *string { return &c.SQLite.Omnimirror }),
+	stringSetting("EMBEDDB_PATH", "embeds SQLite database, off to disable", func(c *config) *string { return &c.SQLite.Embeds }),
+	intSetting("RATE_LIMIT_MAX", "requests allowed per client within the expiration", func(c *config) *int { return &c.RateLimit.Max }),
+	durationSetting("RATE_LIMIT_EXPIRATION", "rate limit window", func(c *config) *time.Duration { return &c.RateLimit.Expiration }),
+	stringSetting("CORS_ALLOW_ORIGINS", "comma-separated allowed origins", func(c *config) *string { return &c.CORS.AllowOrigins }),
+	stringSetting("CORS_ALLOW_METHODS", "comma-separated allowed methods", func(c *config) *string { return &c.CORS.AllowMethods }),
+	stringSetting("CORS_ALLOW_HEADERS", "comma-separated allowed request headers", func(c *config) *string { return &c.CORS.AllowHeaders }),
+	boolSetting("CORS_ALLOW_CREDENTIALS", "allow credentials in CORS requests", func(c *config) *bool { return &c.CORS.AllowCredentials }),
+	intSetting("CORS_MAX_AGE", "seconds browsers can cache the preflight responses", func(c *config) *int { return &c.CORS.MaxAge }),
+	boolSetting("EVENTS_ENABLED", "serve the /events stream", func(c *config) *bool { return &c.Toggles.Events }),
+	boolSetting("LIVE_LOGS_ENABLED", "serve the /logs/live stream", func(c *config) *bool { return &c.Toggles.LiveLogs }),
+	boolSetting("WEBSOCKETS_ENABLED", "serve the /ws subscriptions", func(c *config) *bool { return &c.Toggles.WebSockets }),
+	boolSetting("GRPC_WEB_ENABLED", "serve gRPC-Web on the HTTP port", func(c *config) *bool { return &c.Toggles.GRPCWeb }),
+	boolSetting("WEBHOOKS_ENABLED", "deliver the events to the webhooks", func(c *config) *bool { return &c.Toggles.Webhooks }),
+	boolSetting("PERSIST_STAMPS", "persist the stamps in Redis", func(c *config) *bool { return &c.PersistStamps }),
+	durationSetting("EVENT_DEDUPE_WINDOW", "how long ingested event IDs are remembered", func(c *config) *time.Duration { return &c.EventDedupeWindow }),
+	durationSetting("SHUTDOWN_TIMEOUT", "how long a graceful shutdown can take", func(c *config) *time.Duration { return &c.ShutdownTimeout }),
+	durationSetting("SHUTDOWN_DRAIN_DELAY", "how long /readyz reports the shutdown before the servers start draining", func(c *config) *time.Duration { return &c.ShutdownDrainDelay }),
+	durationSetting("LOGS_CACHE_TTL", "how long finalized log ranges stay cached", func(c *config) *time.Duration { return &c.LogsCache.TTL }),
+	intSetting("LOGS_CACHE_MAX_BYTES", "largest log range response that gets cached", func(c *config) *int { return &c.LogsCache.MaxBytes }),
+}
+
+// configPath and configFlags are kept around so a reload reads the same sources
+var configPath string
+var configFlags map[string]string
+
+// envLookup prefers the real environment over .env, the .env file is read
+// every time instead of being loaded into the environment so edits to it
+// get picked up by a reload
+func envLookup() func(key string) string {
+	dotenv, _ := godotenv.Read()
+	return func(key string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		return dotenv[key]
+	}
+}
+
+// loadConfigFrom applies every source on top of the defaults and
+// returns all the problems it ran into instead of stopping at the first one
+func loadConfigFrom(path string, flagValues map[string]string) (config, []string) {
+	c := defaultConfig()
+	problems := []string{}
+
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("config file: %s", err))
+		} else {
+			decoder := yaml.NewDecoder(bytes.NewReader(raw))
+			decoder.KnownFields(true)
+			if err := decoder.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+				problems = append(problems, fmt.Sprintf("config file %s: %s", path, err))
+			}
+		}
+	}
+
+	env := envLookup()
+	for _, s := range settings {
+		if value := env(s.env); value != "" {
+			if err := s.apply(&c, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", s.env, err))
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := flagValues[s.flagName()]; ok {
+			if err := s.apply(&c, value); err != nil {
+				problems = append(problems, fmt.Sprintf("--%s: %s", s.flagName(), err))
+			}
+		}
+	}
+
+	return c, append(problems, c.validate()...)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n < 65536
+}
+
+func (c config) validate() []string {
+	problems := []string{}
+	required := func(value string, key string, env string) {
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("%s (%s) is required", key, env))
+		}
+	}
+	port := func(value string, key string, env string) {
+		if value != "" && !validPort(value) {
+			problems = append(problems, fmt.Sprintf("%s (%s) needs to be a port number, got %q", key, env, value))
+		}
+	}
+
+	if _, err := log.ParseLevel(c.LogLevel); err != nil {
+		problems = append(problems, fmt.Sprintf("logLevel (LOG_LEVEL) needs to be debug, info, warn, error or fatal, got %q", c.LogLevel))
+	}
+	if _, err := log.ParseFormat(c.LogFormat); err != nil {
+		problems = append(problems, fmt.Sprintf("logFormat (LOG_FORMAT) needs to be text or json, got %q", c.LogFormat))
+	}
+	required(c.Port, "port", "PORT")
+	port(c.Port, "port", "PORT")
+	if len(c.TrustedProxies) == 0 {
+		problems = append(problems, "trustedProxies (TRUSTED_PROXY) is required")
+	}
+	if _, err := parseTrustedProxies(c.TrustedProxies); err != nil {
+		problems = append(problems, fmt.Sprintf("trustedProxies (TRUSTED_PROXY): %s", err))
+	}
+
+	// Postgres and Redis are optional, setting the host turns them on
+	if c.Postgres.Host != "" {
+		required(c.Postgres.User, "postgres.user", "POSTGRES_USER")
+		required(c.Postgres.Password, "postgres.password", "POSTGRES_PASSWORD")
+		required(c.Postgres.Port, "postgres.port", "POSTGRES_PORT")
+		required(c.Postgres.DB, "postgres.db", "POSTGRES_DB")
+	}
+	port(c.Postgres.Port, "postgres.port", "POSTGRES_PORT")
+	if c.Redis.Host != "" {
+		required(c.Redis.Port, "redis.port", "REDIS_PORT")
+	}
+	port(c.Redis.Port, "redis.port", "REDIS_PORT")
+	if c.PersistStamps && c.Redis.Host == "" {
+		problems = append(problems, "persistStamps (PERSIST_STAMPS) needs redis.host (REDIS_HOST)")
+	}
+
+	required(c.GRPC.Port, "grpc.port", "GRPC_PORT")
+	port(c.GRPC.Port, "grpc.port", "GRPC_PORT")
+	if c.GRPC.Port != "" && c.GRPC.Port == c.Port {
+		problems = append(problems, "grpc.port (GRPC_PORT) and port (PORT) can't be the same")
+	}
+	if c.GRPC.Token == "" && !c.GRPC.AllowUnauthenticated {
+		problems = append(problems, "grpc.token (GRPC_TOKEN) is required, set grpc.allowUnauthenticated (GRPC_ALLOW_UNAUTHENTICATED) to accept unauthenticated ingestion RPCs instead")
+	}
+	if (c.GRPC.TLSCert == "") != (c.GRPC.TLSKey == "") {
+		problems = append(problems, "grpc.tlsCert (GRPC_TLS_CERT) and grpc.tlsKey (GRPC_TLS_KEY) need to be set together")
+	}
+	if c.GRPC.TLSClientCA != "" && c.GRPC.TLSCert == "" {
+		problems = append(problems, "grpc.tlsClientCA (GRPC_TLS_CLIENT_CA) needs grpc.tlsCert (GRPC_TLS_CERT) and grpc.tlsKey (GRPC_TLS_KEY)")
+	}
+
+	if c.RateLimit.Max <= 0 {
+		problems = append(problems, "rateLimit.max (RATE_LIMIT_MAX) needs to be positive")
+	}
+	if c.RateLimit.Expiration <= 0 {
+		problems = append(problems, "rateLimit.expiration (RATE_LIMIT_EXPIRATION) needs to be positive")
+	}
+	required(c.CORS.AllowOrigins, "cors.allowOrigins", "CORS_ALLOW_ORIGINS")
+	if c.CORS.AllowCredentials && strings.Contains(c.CORS.AllowOrigins, "*") {
+		problems = append(problems, "cors.allowCredentials (CORS_ALLOW_CREDENTIALS) can't be used with a wildcard cors.allowOrigins (CORS_ALLOW_ORIGINS)")
+	}
+	if c.CORS.MaxAge < 0 {
+		problems = append(problems, "cors.maxAge (CORS_MAX_AGE) can't be negative")
+	}
+
+	if c.EventDedupeWindow <= 0 {
+		problems = append(problems, "eventDedupeWindow (EVENT_DEDUPE_WINDOW) needs to be positive")
+	}
+	if c.ShutdownTimeout <= 0 {
+		problems = append(problems, "shutdownTimeout (SHUTDOWN_TIMEOUT) needs to be positive")
+	}
+	if c.ShutdownDrainDelay < 0 {
+		problems = append(problems, "shutdownDrainDelay (SHUTDOWN_DRAIN_DELAY) can't be negative")
+	} else if c.ShutdownDrainDelay >= c.ShutdownTimeout {
+		problems = append(problems, "shutdownDrainDelay (SHUTDOWN_DRAIN_DELAY) needs to be shorter than shutdownTimeout (SHUTDOWN_TIMEOUT)")
+	}
+	if c.LogsCache.TTL <= 0 {
+		problems = append(problems, "logsCache.ttl (LOGS_CACHE_TTL) needs to be positive")
+	}
+	if c.LogsCache.MaxBytes < 0 {
+		problems = append(problems, "logsCache.maxBytes (LOGS_CACHE_MAX_BYTES) can't be negative")
+	}
+
+	return problems
+}
+
+// redacted is the config with the secrets blanked out, for printing
+func (c config) redacted() config {
+	redact := func(value *string) {
+		if *value != "" {
+			*value = "REDACTED"
+		}
+	}
+	redact(&c.AdminToken)
+	redact(&c.Postgres.Password)
+	redact(&c.Redis.Password)
+	redact(&c.GRPC.Token)
+	return c
+}
+
+// loadConfig fills cfg from the config file, the environment and the
+// command line, every problem gets reported before the server gives up
+func loadConfig() {
+	log.Infof("Loading the configuration")
+	fs := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ExitOnError)
+	path := fs.String("config", envLookup()("CONFIG_FILE"), "YAML config file (CONFIG_FILE)")
+	printConfig := fs.Bool("print-config", false, "print the resulting configuration with the secrets redacted and exit")
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		name := s.flagName()
+		fs.Func(name, fmt.Sprintf("%s (%s)", s.usage, s.env), func(value string) error {
+			flagValues[name] = value
+			return nil
+		})
+	}
+	fs.Parse(os.Args[1:])
+
+	c, problems := loadConfigFrom(*path, flagValues)
+
+	if *printConfig {
+		out, err := yaml.Marshal(c.redacted())
+		if err != nil {
+			log.Fatalf("Couldn't marshal the configuration: %s", err)
+		}
+		os.Stdout.Write(out)
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			log.Errorf("Configuration problem: %s", problem)
+		}
+		log.Fatalf("Found %d configuration problem(s), please fix them and restart the server", len(problems))
+	}
+
+	if *printConfig {
+		os.Exit(0)
+	}
+
+	cfg = c
+	configPath = *path
+	configFlags = flagValues
+	applyConfig(&c)
+
+	loc, err := time.LoadLocation("UTC")
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+	time.Local = loc
+
+	log.Infof("Configuration loaded successfully")
+}
@@ -2,17 +2,20 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 	_ "time/tzdata"
 
-	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgtype"
 	_ "github.com/mattn/go-sqlite3"
 )
 
-func nukes() ([]nuke, error) {
+func queryNukes() ([]nuke, error) {
 	logs := []logLine{}
 	countRaw := []logLine{}
 	countStamps := []time.Time{}
@@ -218,7 +221,7 @@ func nukes() ([]nuke, error) {
 	return data, nil
 }
 
-func phrases(countString string) ([]phrase, error) {
+func queryPhrases(countString string) ([]phrase, error) {
 	phrases := []phrase{}
 
 	if countString != "" {
@@ -265,7 +268,7 @@ func phrases(countString string) ([]phrase, error) {
 	return phrases, nil
 }
 
-func mutelinks() ([]fiber.Map, error) {
+func queryMutelinks() ([]mutelinksStatus, error) {
 	logs := []logLine{}
 
 	rows, err := pg.Query(context.Background(), "select * from mutelinks where message ~* '^(!mutelinks|!mutelink|!linkmute|!linksmute)' and features ~ '(moderator|admin)' order by time desc FETCH FIRST 1 ROWS ONLY")
@@ -298,19 +301,19 @@ func mutelinks() ([]fiber.Map, error) {
 				match := mutelinksRegex.FindAllStringSubmatch(theRest, -1)
 				for i := range match {
 					if len(match[i][2]) != 0 {
-						return []fiber.Map{{
-							"time":     line.Time,
-							"status":   match[i][1],
-							"duration": match[i][2],
-							"user":     line.Username,
+						return []mutelinksStatus{{
+							Time:     line.Time,
+							Status:   match[i][1],
+							Duration: match[i][2],
+							User:     line.Username,
 						},
 						}, nil
 					} else {
-						return []fiber.Map{{
-							"time":     line.Time,
-							"status":   match[i][1],
-							"duration": "10m",
-							"user":     line.Username,
+						return []mutelinksStatus{{
+							Time:     line.Time,
+							Status:   match[i][1],
+							Duration: "10m",
+							User:     line.Username,
 						},
 						}, nil
 					}
@@ -321,3 +324,329 @@ func mutelinks() ([]fiber.Map, error) {
 
 	return nil, nil
 }
+
+func queryFeatures() (map[string]string, error) {
+	feats := make(map[string]string)
+
+	rows, err := featdb.Query("SELECT * from dggfeat")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := feature{}
+		err := rows.Scan(&p.Username, &p.Feat)
+		if err != nil {
+			continue
+		}
+		feats[p.Username] = p.Feat
+	}
+
+	return feats, rows.Err()
+}
+
+func queryYTvods() ([]ytvod, error) {
+	ytvods := []ytvod{}
+
+	rows, err := ytvoddb.Query("SELECT vodid, title, starttime, endtime, thumbnail from ytvods ORDER BY datetime(starttime) DESC LIMIT 45")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := ytvod{}
+		err := rows.Scan(&p.ID, &p.Title, &p.Start, &p.End, &p.Thumbnail)
+		if err != nil {
+			continue
+		}
+		ytvods = append(ytvods, p)
+	}
+
+	return ytvods, nil
+}
+
+// queryRumbleVods works for both rumbledb and omnimirrordb, they share a schema
+func queryRumbleVods(db *sql.DB) ([]rumblevod, error) {
+	rumblevods := []rumblevod{}
+
+	rows, err := db.Query("SELECT public_id, embed_id, title, link, thumbnail, start_time, end_time from rumble ORDER BY datetime(start_time) DESC LIMIT 45")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := rumblevod{}
+		err := rows.Scan(&p.PublicID, &p.EmbedID, &p.Title, &p.Link, &p.Thumbnail, &p.Start, &p.End)
+		if err != nil {
+			continue
+		}
+		rumblevods = append(rumblevods, p)
+	}
+
+	return rumblevods, nil
+}
+
+func queryLWOD() (lwodDataset, error) {
+	dataset := lwodDataset{
+		all:     []lwod{},
+		twitch:  make(map[string][]lwodTwitch),
+		youtube: make(map[string][]lwodYT),
+	}
+
+	rows, err := lwoddb.Query("SELECT vodid, vidid, starttime, endtime, yttime, game, subject, topic from lwod")
+	if err != nil {
+		return dataset, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var vodid, vidid, start, end sql.NullString
+		var ytTime sql.NullInt64
+		var game, subject, topic string
+		err := rows.Scan(&vodid, &vidid, &start, &end, &ytTime, &game, &subject, &topic)
+		if err != nil {
+			continue
+		}
+
+		if start.Valid && end.Valid {
+			p := lwod{
+				Start:   start.String,
+				End:     end.String,
+				Game:    game,
+				Subject: subject,
+				Topic:   topic,
+			}
+			if vodid.Valid {
+				p.Twitch = &vodid.String
+				dataset.twitch[vodid.String] = append(dataset.twitch[vodid.String], lwodTwitch{
+					Start:   start.String,
+					End:     end.String,
+					Game:    game,
+					Subject: subject,
+					Topic:   topic,
+				})
+			}
+			if vidid.Valid {
+				p.YouTube = &vidid.String
+			}
+			dataset.all = append(dataset.all, p)
+		}
+
+		if vidid.Valid && ytTime.Valid {
+			dataset.youtube[vidid.String] = append(dataset.youtube[vidid.String], lwodYT{
+				Time:    int(ytTime.Int64),
+				Game:    game,
+				Subject: subject,
+				Topic:   topic,
+			})
+		}
+	}
+
+	for _, entries := range dataset.youtube {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Time < entries[j].Time
+		})
+	}
+
+	return dataset, rows.Err()
+}
+
+func validatePhrase(p phrase) error {
+	if strings.TrimSpace(p.Phrase) == "" {
+		return fmt.Errorf("phrase is empty")
+	}
+	if len(p.Phrase) > 2 && strings.HasPrefix(p.Phrase, "/") && strings.HasSuffix(p.Phrase, "/") {
+		if _, err := regexp.Compile(p.Phrase[1 : len(p.Phrase)-1]); err != nil {
+			return fmt.Errorf("invalid regex: %s", err)
+		}
+	}
+	if p.Duration == "" {
+		return fmt.Errorf("duration is empty")
+	}
+	if p.Type == "" {
+		return fmt.Errorf("type is empty")
+	}
+	return nil
+}
+
+func importPhrases(incoming []phrase, overwrite bool, dryRun bool) (phraseImportReport, error) {
+	report := phraseImportReport{
+		DryRun:    dryRun,
+		Conflicts: []phraseConflict{},
+		Invalid:   []phraseInvalid{},
+	}
+
+	// skip the coalescing, the import has to see the phrases as they are now
+	existing, err := queryPhrases("")
+	if err != nil {
+		return report, err
+	}
+	existingMap := make(map[string]phrase)
+	for _, p := range existing {
+		existingMap[p.Phrase] = p
+	}
+
+	toInsert := []phrase{}
+	toReplace := []phrase{}
+	seen := make(map[string]int)
+	for i, p := range incoming {
+		if p.Time.IsZero() {
+			p.Time = time.Now()
+		}
+		if err := validatePhrase(p); err != nil {
+			report.Invalid = append(report.Invalid, phraseInvalid{
+				Index:  i,
+				Phrase: p.Phrase,
+				Error:  err.Error(),
+			})
+			continue
+		}
+		if first, ok := seen[p.Phrase]; ok {
+			report.Invalid = append(report.Invalid, phraseInvalid{
+				Index:  i,
+				Phrase: p.Phrase,
+				Error:  fmt.Sprintf("duplicate of entry %d", first),
+			})
+			continue
+		}
+		seen[p.Phrase] = i
+
+		if old, ok := existingMap[p.Phrase]; ok {
+			if old.Duration == p.Duration && old.Type == p.Type && old.Username == p.Username {
+				report.Unchanged++
+				continue
+			}
+			report.Conflicts = append(report.Conflicts, phraseConflict{
+				Index:    i,
+				Existing: old,
+				Incoming: p,
+			})
+			if overwrite {
+				toReplace = append(toReplace, p)
+			}
+			continue
+		}
+		toInsert = append(toInsert, p)
+	}
+
+	if len(report.Invalid) > 0 || dryRun {
+		report.Imported = len(toInsert)
+		report.Replaced = len(toReplace)
+		return report, nil
+	}
+
+	tx, err := pg.Begin(context.Background())
+	if err != nil {
+		return report, err
+	}
+	defer tx.Rollback(context.Background())
+
+	for _, p := range toReplace {
+		_, err := tx.Exec(context.Background(), "delete from phrases where phrase = $1", p.Phrase)
+		if err != nil {
+			return report, err
+		}
+	}
+	for _, p := range append(toInsert, toReplace...) {
+		_, err := tx.Exec(context.Background(), "insert into phrases (time, username, phrase, duration, type) values ($1, $2, $3, $4, $5)", p.Time, p.Username, p.Phrase, p.Duration, p.Type)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return report, err
+	}
+
+	report.Imported = len(toInsert)
+	report.Replaced = len(toReplace)
+	return report, nil
+}
+
+func queryEmbeds(minutes int) ([]embed, error) {
+	embeds := []embed{}
+
+	rows, err := embeddb.Query("select link, platform, channel, title, count(link) as freq from embeds where timest >= strftime('%s', 'now') - $1 group by link order by freq desc limit 5", minutes*60)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := embed{}
+		err := rows.Scan(&p.Link, &p.Platform, &p.Channel, &p.Title, &p.Count)
+		if err != nil {
+			continue
+		}
+		embeds = append(embeds, p)
+	}
+
+	return embeds, nil
+}
+
+func queryLastEmbeds() ([]lastembed, error) {
+	lastembeds := []lastembed{}
+
+	rows, err := embeddb.Query("select timest, link, platform, channel, title from embeds order by timest desc limit 5")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := lastembed{}
+		err := rows.Scan(&p.Timestamp, &p.Link, &p.Platform, &p.Channel, &p.Title)
+		if err != nil {
+			continue
+		}
+		lastembeds = append(lastembeds, p)
+	}
+
+	return lastembeds, nil
+}
+
+func queryLogs(from string, to string) (map[int64][]pgtype.JSON, error) {
+	logs := make(map[int64][]pgtype.JSON)
+
+	rows, err := pg.Query(context.Background(), "SELECT extract(epoch from date_trunc('second', time)), array_agg(json_build_object('username', username, 'features', features, 'message', message)) FROM logs WHERE time >= $1 AND time < $2 GROUP BY date_trunc('second', time) ORDER BY date_trunc('second', time)", from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := logGroup{}
+		err := rows.Scan(&p.Time, &p.Lines)
+		if err != nil {
+			continue
+		}
+		logs[p.Time] = p.Lines.Elements
+	}
+
+	return logs, rows.Err()
+}
+
+func queryRawLogs(from string, to string, fn func(logLineString) error) error {
+	rows, err := pg.Query(context.Background(), "SELECT to_char(time, 'YYYY-MM-DD\"T\"HH24:MI:SS.MSZ'), username, features, message FROM logs WHERE time >= $1 AND time < $2 ORDER BY time", from, to)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := logLineString{}
+		err := rows.Scan(&p.Time, &p.Username, &p.Features, &p.Message)
+		if err != nil {
+			continue
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
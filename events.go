@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+	pb "github.com/vyneer/vyneer-api/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	eventPhrase        = "phrase"
+	eventPhraseRemoval = "phraseRemoval"
+	eventNuke          = "nuke"
+	eventAegis         = "aegis"
+	eventMutelinks     = "mutelinks"
+)
+
+type event struct {
+	Type     string
+	Time     int64
+	Instance string
+	Data     proto.Message
+}
+
+type eventJSON struct {
+	Type     string          `json:"type"`
+	Time     int64           `json:"time"`
+	Instance string          `json:"instance,omitempty"`
+	Data     json.RawMessage `json:"data"`
+}
+
+func newEventData(eventType string) (proto.Message, error) {
+	switch eventType {
+	case eventPhrase:
+		return &pb.Phrase{}, nil
+	case eventPhraseRemoval:
+		return &pb.RemovePhrase{}, nil
+	case eventNuke:
+		return &pb.Nuke{}, nil
+	case eventAegis:
+		return &pb.Aegis{}, nil
+	case eventMutelinks:
+		return &pb.Mutelinks{}, nil
+	}
+	return nil, fmt.Errorf("unknown event type: %s", eventType)
+}
+
+func (e event) MarshalJSON() ([]byte, error) {
+	data, err := protojson.Marshal(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(eventJSON{
+		Type:     e.Type,
+		Time:     e.Time,
+		Instance: e.Instance,
+		Data:     data,
+	})
+}
+
+func (e *event) UnmarshalJSON(b []byte) error {
+	raw := eventJSON{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	data, err := newEventData(raw.Type)
+	if err != nil {
+		return err
+	}
+	if err := protojson.Unmarshal(raw.Data, data); err != nil {
+		return err
+	}
+	e.Type = raw.Type
+	e.Time = raw.Time
+	e.Instance = raw.Instance
+	e.Data = data
+	return nil
+}
+
+// origin describes where the event came from for the logs
+func (e event) origin() string {
+	if e.Instance == instanceID {
+		return "gRPC"
+	}
+	return "fanned out"
+}
+
+type broker[T any] struct {
+	mu   sync.RWMutex
+	size int
+	subs map[chan T]struct{}
+}
+
+var events = newBroker[event](16)
+
+func newBroker[T any](size int) *broker[T] {
+	return &broker[T]{
+		size: size,
+		subs: make(map[chan T]struct{}),
+	}
+}
+
+func (b *broker[T]) subscribe() chan T {
+	ch := make(chan T, b.size)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broker[T]) unsubscribe(ch chan T) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+}
+
+// publish never blocks the publisher, so a subscriber that
+// can't keep up just misses messages
+func (b *broker[T]) publish(msg T) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subs {
+		select {
+		case ch <- msg:
+		default:
+			log.Warnf("Dropping a %T message for a slow subscriber", msg)
+		}
+	}
+}
+
+func getEvents(c *fiber.Ctx) error {
+	types := make(map[string]bool)
+	if c.Query("types") != "" {
+		for _, t := range strings.Split(c.Query("types"), ",") {
+			switch t {
+			case eventPhrase, eventPhraseRemoval, eventNuke, eventAegis, eventMutelinks:
+				types[t] = true
+			default:
+				return c.Status(400).SendString(fmt.Sprintf("Unknown event type: %s", t))
+			}
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ch := events.subscribe()
+		defer events.unsubscribe(ch)
+
+		keepAlive := time.NewTicker(time.Second * 15)
+		defer keepAlive.Stop()
+
+		fmt.Fprintf(w, "retry: 5000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-serverCtx.Done():
+				return
+			case e := <-ch:
+				if len(types) > 0 && !types[e.Type] {
+					continue
+				}
+				data, err := json.Marshal(e)
+				if err != nil {
+					log.Errorf("Couldn't marshal a %s event: %s", e.Type, err)
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+			case <-keepAlive.C:
+				fmt.Fprintf(w, ": keepalive\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
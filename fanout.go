@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+	_ "time/tzdata"
+
+	log "github.com/vyneer/vyneer-api/logger"
+	pb "github.com/vyneer/vyneer-api/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const eventsChannel = "vyneer-api:events"
+
+// instanceID tells the replicas apart, so every replica can skip the
+// events it published itself when they come back through Redis
+var instanceID = newInstanceID()
+
+func newInstanceID() string {
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(buf))
+}
+
+// publishFanout sends an event this replica ingested to the other replicas
+func publishFanout(e event) {
+	if rdb == nil {
+		return
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf("Couldn't marshal a %s event for fan-out: %s", e.Type, err)
+		return
+	}
+	if err := rdb.Publish(context.Background(), eventsChannel, payload).Err(); err != nil {
+		log.Errorf("Couldn't publish a %s event to redis: %s", e.Type, err)
+	}
+}
+
+// bumpPhrases moves the removal stamp for a change that didn't come in as an
+// event, like an import, the other replicas get it as a phrase removal event
+// since reconcileStamp would never notice older phrases being restored
+func bumpPhrases(reason string) {
+	now := time.Now()
+	e := event{
+		Type:     eventPhraseRemoval,
+		Time:     now.UnixMilli(),
+		Instance: instanceID,
+		Data:     &pb.RemovePhrase{Time: timestamppb.New(now), Source: "vyneer-api"},
+	}
+	stamps.update(stampPhraseRemoval, e.Time, reason)
+	events.publish(e)
+	publishFanout(e)
+}
+
+func subscribeFanout(ctx context.Context) {
+	sub := rdb.Subscribe(ctx, eventsChannel)
+	defer sub.Close()
+
+	log.Infof("Subscribed to the %s redis channel as %s", eventsChannel, instanceID)
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			e := event{}
+			if err := json.Unmarshal([]byte(msg.Payload), &e); err != nil {
+				log.Errorf("Couldn't unmarshal a fanned out event: %s", err)
+				continue
+			}
+			if e.Instance == instanceID {
+				continue
+			}
+			ingested.observe(e)
+			applyEvent(e)
+		}
+	}
+}
@@ -2,137 +2,500 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 	_ "time/tzdata"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v4"
 	_ "github.com/mattn/go-sqlite3"
 	log "github.com/vyneer/vyneer-api/logger"
 	"github.com/vyneer/vyneer-api/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type server struct {
 	proto.UnimplementedStatusServer
 }
 
-var phraseStamp int64 = 0
-var phraseRemovalStamp int64 = 0
-var nukeStamp int64 = 0
-var mutelinksStamp int64 = 0
+// observedStamps keeps the newest database timestamps doubleCheckStamps
+// saw last time, so it can tell when the newest row got removed
+var observedStamps = make(map[string]int64)
 
 func (s *server) ReceiveRemovePhrase(ctx context.Context, in *proto.RemovePhrase) (*proto.Empty, error) {
-	newStamp := in.Time.AsTime().UnixMilli()
-	log.Infof("Received a gRPC phrase removal event, updating the phraseRemovalStamp variable: %+v -> %+v", phraseRemovalStamp, newStamp)
-	phraseRemovalStamp = newStamp
+	if err := ingest(event{Type: eventPhraseRemoval, Time: in.Time.AsTime().UnixMilli(), Data: in}); err != nil {
+		return nil, err
+	}
 	return &proto.Empty{}, nil
 }
 
 func (s *server) ReceivePhrase(ctx context.Context, in *proto.Phrase) (*proto.Empty, error) {
-	newStamp := in.Time.AsTime().UnixMilli()
-	log.Infof("Received a gRPC phrase event, updating the phraseStamp variable: %+v -> %+v", phraseStamp, newStamp)
-	phraseStamp = newStamp
+	if err := ingest(event{Type: eventPhrase, Time: in.Time.AsTime().UnixMilli(), Data: in}); err != nil {
+		return nil, err
+	}
 	return &proto.Empty{}, nil
 }
 
 func (s *server) ReceiveNuke(ctx context.Context, in *proto.Nuke) (*proto.Empty, error) {
-	newStamp := in.Time.AsTime().UnixMilli()
-	log.Infof("Received a gRPC nuke event, updating the nukeStamp variable: %+v -> %+v", nukeStamp, newStamp)
-	nukeStamp = newStamp
-	go func() {
-		time.Sleep(time.Minute * 5)
-		nukeStamp = time.Now().UnixMilli()
-	}()
+	if err := ingest(event{Type: eventNuke, Time: in.Time.AsTime().UnixMilli(), Data: in}); err != nil {
+		return nil, err
+	}
 	return &proto.Empty{}, nil
 }
 
 func (s *server) ReceiveAegis(ctx context.Context, in *proto.Aegis) (*proto.Empty, error) {
-	newStamp := in.Time.AsTime().UnixMilli()
-	log.Infof("Received a gRPC aegis event, updating the nukeStamp variable: %+v -> %+v", nukeStamp, newStamp)
-	nukeStamp = newStamp
+	if err := ingest(event{Type: eventAegis, Time: in.Time.AsTime().UnixMilli(), Data: in}); err != nil {
+		return nil, err
+	}
 	return &proto.Empty{}, nil
 }
 
 func (s *server) ReceiveMutelinks(ctx context.Context, in *proto.Mutelinks) (*proto.Empty, error) {
-	newStamp := in.Time.AsTime().UnixMilli()
-	log.Infof("Received a gRPC nuke event, updating the mutelinksStamp variable: %+v -> %+v", mutelinksStamp, newStamp)
-	mutelinksStamp = newStamp
+	if err := ingest(event{Type: eventMutelinks, Time: in.Time.AsTime().UnixMilli(), Data: in}); err != nil {
+		return nil, err
+	}
 	return &proto.Empty{}, nil
 }
 
-func gRPCServer() {
-	listener, err := net.Listen("tcp", ":6413")
+func (s *server) Subscribe(in *proto.SubscribeRequest, stream proto.Status_SubscribeServer) error {
+	types := make(map[proto.EventType]bool)
+	for _, t := range in.Types {
+		if t == proto.EventType_EVENT_TYPE_UNSPECIFIED {
+			return status.Error(codes.InvalidArgument, "the event types need to be specified")
+		}
+		types[t] = true
+	}
+
+	ch := events.subscribe()
+	defer events.unsubscribe(ch)
+
+	// send the headers right away, gRPC-Web clients
+	// wait for them before they consider the stream open
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-serverCtx.Done():
+			return status.Error(codes.Unavailable, "the server is shutting down")
+		case e := <-ch:
+			msg, eventType := toProtoEvent(e)
+			if msg == nil || (len(types) > 0 && !types[eventType]) {
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) GetPhrases(ctx context.Context, in *proto.PhrasesRequest) (*proto.PhraseList, error) {
+	if err := requireStore(storePhrases); err != nil {
+		return nil, err
+	}
+	countString := ""
+	if in.Count > 0 {
+		countString = strconv.Itoa(int(in.Count))
+	}
+	data, err := phrases(countString)
 	if err != nil {
-		log.Fatalf("Couldn't create gRPC server", time.Now().Format("2006-01-02 15:04:05.000000 MST"))
+		log.Errorf("GetPhrases - Phrases error: %s", err)
+		return nil, status.Error(codes.Internal, "couldn't get the phrases")
 	}
 
-	s := grpc.NewServer()
-	reflection.Register(s)
-	proto.RegisterStatusServer(s, &server{})
-	log.Infof("Starting a gRPC server on port 6413")
-	if err := s.Serve(listener); err != nil {
-		log.Fatalf("Failed to serve: %v", time.Now().Format("2006-01-02 15:04:05.000000 MST"), err)
+	list := &proto.PhraseList{}
+	for _, p := range data {
+		list.Phrases = append(list.Phrases, &proto.Phrase{
+			Time:     timestamppb.New(p.Time),
+			Username: p.Username,
+			Phrase:   p.Phrase,
+			Duration: p.Duration,
+			Type:     p.Type,
+		})
 	}
+	return list, nil
 }
 
-func doubleCheckStamps() error {
-	rowPhrase := pg.QueryRow(context.Background(), "select time from phrases order by time desc limit 1;")
-	phraseStampInner := logLine{}
-	err := rowPhrase.Scan(&phraseStampInner.Time)
+func (s *server) GetNukes(ctx context.Context, in *proto.Empty) (*proto.NukeList, error) {
+	if err := requireStore(storeNukes); err != nil {
+		return nil, err
+	}
+	data, err := nukes()
 	if err != nil {
-		phraseStampInner.Time = time.Unix(0, 0)
+		log.Errorf("GetNukes - Nukes error: %s", err)
+		return nil, status.Error(codes.Internal, "couldn't get the nukes")
 	}
 
-	rowNuke := pg.QueryRow(context.Background(), "select time from nukes where username != 'Bot' order by time desc limit 1;")
-	nukeStampInner := logLine{}
-	err = rowNuke.Scan(&nukeStampInner.Time)
+	list := &proto.NukeList{}
+	for _, n := range data {
+		list.Nukes = append(list.Nukes, &proto.Nuke{
+			Time:     timestamppb.New(n.Time),
+			Type:     n.Type,
+			Duration: n.Duration,
+			Word:     n.Word,
+			Victims:  n.Victims,
+		})
+	}
+	return list, nil
+}
+
+func (s *server) GetMutelinks(ctx context.Context, in *proto.Empty) (*proto.MutelinksList, error) {
+	if err := requireStore(storeMutelinks); err != nil {
+		return nil, err
+	}
+	data, err := mutelinks()
 	if err != nil {
-		nukeStampInner.Time = time.Unix(0, 0)
+		log.Errorf("GetMutelinks - Mutelinks error: %s", err)
+		return nil, status.Error(codes.Internal, "couldn't get the mutelinks")
+	}
+
+	list := &proto.MutelinksList{}
+	for _, m := range data {
+		list.Mutelinks = append(list.Mutelinks, &proto.Mutelinks{
+			Time:     timestamppb.New(m.Time),
+			Status:   m.Status,
+			Duration: m.Duration,
+			User:     m.User,
+		})
 	}
+	return list, nil
+}
 
-	rowMutelinks := pg.QueryRow(context.Background(), "select time from mutelinks order by time desc limit 1;")
-	mutelinksStampInner := logLine{}
-	err = rowMutelinks.Scan(&mutelinksStampInner.Time)
+func (s *server) GetRawLogs(in *proto.RawLogsRequest, stream proto.Status_GetRawLogsServer) error {
+	if err := requireStore(storeLogs); err != nil {
+		return err
+	}
+	if in.From == nil || in.To == nil {
+		return status.Error(codes.InvalidArgument, "both from and to need to be provided")
+	}
+
+	err := rawLogs(in.From.AsTime().Format(time.RFC3339Nano), in.To.AsTime().Format(time.RFC3339Nano), func(p logLineString) error {
+		t, err := time.Parse(logLineStringLayout, p.Time)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&proto.LogLine{
+			Time:     timestamppb.New(t),
+			Username: p.Username,
+			Features: p.Features,
+			Message:  p.Message,
+		})
+	})
 	if err != nil {
-		mutelinksStampInner.Time = time.Unix(0, 0)
+		if stream.Context().Err() != nil {
+			return nil
+		}
+		log.Errorf("GetRawLogs - Postgres query error: %s", err)
+		return status.Error(codes.Internal, "couldn't get the logs")
 	}
+	return nil
+}
 
-	phraseStampInnerMilli := phraseStampInner.Time.UnixMilli()
-	nukeStampInnerMilli := nukeStampInner.Time.UnixMilli()
-	mutelinksStampInnerMilli := mutelinksStampInner.Time.UnixMilli()
+func (s *server) GetEmbeds(ctx context.Context, in *proto.EmbedsRequest) (*proto.EmbedList, error) {
+	if err := requireStore(storeEmbeds); err != nil {
+		return nil, err
+	}
+	list := &proto.EmbedList{}
 
-	if phraseStampInnerMilli != phraseStamp {
-		log.Infof("Updating the phraseStamp variable with the proper timestamp: %+v -> %+v", phraseStamp, phraseStampInner.Time.UnixMilli())
-		phraseStamp = phraseStampInnerMilli
+	if in.Last {
+		data, err := lastEmbeds()
+		if err != nil {
+			log.Errorf("GetEmbeds - embeddb query error: %s", err)
+			return nil, status.Error(codes.Internal, "couldn't get the embeds")
+		}
+		for _, e := range data {
+			list.Embeds = append(list.Embeds, &proto.Embed{
+				Link:     e.Link,
+				Platform: e.Platform,
+				Channel:  e.Channel,
+				Title:    e.Title,
+				Time:     timestamppb.New(time.Unix(int64(e.Timestamp), 0)),
+			})
+		}
+		return list, nil
 	}
 
-	if nukeStampInnerMilli > nukeStamp {
-		log.Infof("Updating the nukeStamp variable with the proper timestamp: %+v -> %+v", nukeStamp, nukeStampInner.Time.UnixMilli())
-		nukeStamp = nukeStampInnerMilli
+	if in.Minutes < 5 || in.Minutes > 60 {
+		return nil, status.Error(codes.InvalidArgument, "minutes need to be between 5 and 60")
+	}
+	data, err := embeds(int(in.Minutes))
+	if err != nil {
+		log.Errorf("GetEmbeds - embeddb query error: %s", err)
+		return nil, status.Error(codes.Internal, "couldn't get the embeds")
+	}
+	for _, e := range data {
+		list.Embeds = append(list.Embeds, &proto.Embed{
+			Link:     e.Link,
+			Platform: e.Platform,
+			Channel:  e.Channel,
+			Title:    e.Title,
+			Count:    int32(e.Count),
+		})
 	}
+	return list, nil
+}
+
+func toProtoEvent(e event) (*proto.Event, proto.EventType) {
+	switch data := e.Data.(type) {
+	case *proto.Phrase:
+		return &proto.Event{Event: &proto.Event_Phrase{Phrase: data}}, proto.EventType_PHRASE
+	case *proto.RemovePhrase:
+		return &proto.Event{Event: &proto.Event_RemovePhrase{RemovePhrase: data}}, proto.EventType_REMOVE_PHRASE
+	case *proto.Nuke:
+		return &proto.Event{Event: &proto.Event_Nuke{Nuke: data}}, proto.EventType_NUKE
+	case *proto.Aegis:
+		return &proto.Event{Event: &proto.Event_Aegis{Aegis: data}}, proto.EventType_AEGIS
+	case *proto.Mutelinks:
+		return &proto.Event{Event: &proto.Event_Mutelinks{Mutelinks: data}}, proto.EventType_MUTELINKS
+	}
+	return nil, proto.EventType_EVENT_TYPE_UNSPECIFIED
+}
 
-	if mutelinksStampInnerMilli != mutelinksStamp {
-		log.Infof("Updating the mutelinksStamp variable with the proper timestamp: %+v -> %+v", mutelinksStamp, mutelinksStampInner.Time.UnixMilli())
-		mutelinksStamp = mutelinksStampInnerMilli
+// applyEvent updates the stamps and notifies the local subscribers,
+// it runs for events received over gRPC and for the ones other
+// replicas fan out through Redis
+func applyEvent(e event) {
+	switch e.Type {
+	case eventPhraseRemoval:
+		stamps.update(stampPhraseRemoval, e.Time, fmt.Sprintf("Received a %s phrase removal event", e.origin()))
+	case eventPhrase:
+		stamps.update(stampPhrases, e.Time, fmt.Sprintf("Received a %s phrase event", e.origin()))
+	case eventNuke:
+		stamps.update(stampNukes, e.Time, fmt.Sprintf("Received a %s nuke event", e.origin()))
+	case eventAegis:
+		stamps.update(stampNukes, e.Time, fmt.Sprintf("Received a %s aegis event", e.origin()))
+	case eventMutelinks:
+		stamps.update(stampMutelinks, e.Time, fmt.Sprintf("Received a %s mutelinks event", e.origin()))
+	}
+	scheduleExpiry(e)
+	events.publish(e)
+}
+
+// ingestionMethods is the prefix of the RPCs that move the stamps
+const ingestionMethods = "/grpc_timestamps.Status/Receive"
+
+var grpcServer *grpc.Server
+
+// grpcAddr is set once the gRPC listener is bound, /readyz dials it
+var grpcAddr atomic.Pointer[net.TCPAddr]
+
+func newGRPCServer() *grpc.Server {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(metricsInterceptor, authInterceptor),
+	}
+	if cfg.GRPC.TLSCert != "" {
+		creds, err := grpcCredentials()
+		if err != nil {
+			log.Fatalf("Couldn't load the gRPC TLS credentials: %s", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+		if cfg.GRPC.TLSClientCA != "" {
+			log.Infof("gRPC mTLS is enabled, client certificates are required")
+		} else {
+			log.Infof("gRPC TLS is enabled")
+		}
+	}
+	if cfg.GRPC.Token == "" && cfg.GRPC.AllowUnauthenticated {
+		log.Warnf("GRPC_TOKEN isn't set and GRPC_ALLOW_UNAUTHENTICATED is on, the gRPC ingestion RPCs accept unauthenticated calls")
+	}
+
+	s := grpc.NewServer(opts...)
+	reflection.Register(s)
+	proto.RegisterStatusServer(s, &server{})
+	return s
+}
+
+func gRPCServer() {
+	listener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+	if err != nil {
+		log.Fatalf("Couldn't create gRPC server: %s", err)
+	}
+
+	log.Infof("Starting a gRPC server on port %s", cfg.GRPC.Port)
+	grpcAddr.Store(listener.Addr().(*net.TCPAddr))
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+func grpcCredentials() (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.GRPC.TLSCert, cfg.GRPC.TLSKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.GRPC.TLSClientCA != "" {
+		ca, err := os.ReadFile(cfg.GRPC.TLSClientCA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.GRPC.TLSClientCA)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// authInterceptor requires the GRPC_TOKEN bearer token on every ingestion
+// RPC, the read RPCs stay open just like the REST endpoints, without a
+// token the ingestion RPCs are only open if that was explicitly allowed
+func authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, ingestionMethods) {
+		return handler(ctx, req)
+	}
+	if cfg.GRPC.Token == "" {
+		if cfg.GRPC.AllowUnauthenticated {
+			return handler(ctx, req)
+		}
+		return nil, status.Error(codes.Unauthenticated, "the server has no token configured")
+	}
+
+	addr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		log.Errorf("Rejected an unauthenticated %s call from %s - no token", info.FullMethod, addr)
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+
+	token := strings.TrimPrefix(md.Get("authorization")[0], "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.GRPC.Token)) != 1 {
+		log.Errorf("Rejected an unauthenticated %s call from %s - invalid token", info.FullMethod, addr)
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization token")
+	}
+
+	return handler(ctx, req)
+}
+
+func doubleCheckStamps() error {
+	if storeAvailable(storePhrases) {
+		rowPhrase := pg.QueryRow(context.Background(), "select time from phrases order by time desc limit 1;")
+		phraseStampInner := logLine{}
+		err := rowPhrase.Scan(&phraseStampInner.Time)
+		if err != nil {
+			phraseStampInner.Time = time.Unix(0, 0)
+		}
+		if stampQueried(stampPhrases, err) {
+			reconcileStamp(stampPhrases, stampPhraseRemoval, phraseStampInner.Time.UnixMilli())
+		}
+	}
+
+	if storeAvailable(storeNukes) {
+		rowNuke := pg.QueryRow(context.Background(), "select time from nukes where username != 'Bot' order by time desc limit 1;")
+		nukeStampInner := logLine{}
+		err := rowNuke.Scan(&nukeStampInner.Time)
+		if err != nil {
+			nukeStampInner.Time = time.Unix(0, 0)
+		}
+		if stampQueried(stampNukes, err) {
+			reconcileStamp(stampNukes, stampNukes, nukeStampInner.Time.UnixMilli())
+		}
+	}
+
+	if storeAvailable(storeMutelinks) {
+		rowMutelinks := pg.QueryRow(context.Background(), "select time from mutelinks order by time desc limit 1;")
+		mutelinksStampInner := logLine{}
+		err := rowMutelinks.Scan(&mutelinksStampInner.Time)
+		if err != nil {
+			mutelinksStampInner.Time = time.Unix(0, 0)
+		}
+		if stampQueried(stampMutelinks, err) {
+			reconcileStamp(stampMutelinks, stampMutelinks, mutelinksStampInner.Time.UnixMilli())
+		}
+	}
+
+	if storeAvailable(storeEmbeds) {
+		rowEmbeds := embeddb.QueryRow("select timest from embeds order by timest desc limit 1;")
+		embedsStampInner := lastembed{}
+		err := rowEmbeds.Scan(&embedsStampInner.Timestamp)
+		if err != nil {
+			embedsStampInner.Timestamp = 0
+		}
+		if stampQueried(stampEmbeds, err) {
+			reconcileStamp(stampEmbeds, stampEmbeds, int64(embedsStampInner.Timestamp)*1000)
+		}
 	}
 
 	return nil
 }
 
-func checkStamps(c *fiber.Ctx) error {
-	if phraseStamp >= phraseRemovalStamp {
-		return c.JSON(fiber.Map{
-			"phrases":   phraseStamp,
-			"nukes":     nukeStamp,
-			"mutelinks": mutelinksStamp,
-		})
-	} else {
-		return c.JSON(fiber.Map{
-			"phrases":   phraseRemovalStamp,
-			"nukes":     nukeStamp,
-			"mutelinks": mutelinksStamp,
-		})
+func watchStamps(ctx context.Context) {
+	ticker := time.NewTicker(time.Second * 15)
+	defer ticker.Stop()
+
+	for {
+		doubleCheckStamps()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
+
+// stampQueried tells an empty table, which reconciles to a 0 stamp, apart
+// from a failed query, which has to be skipped or it'd look like the
+// newest row got removed and bump the removal stamp
+func stampQueried(name string, err error) bool {
+	if err == nil || errors.Is(err, pgx.ErrNoRows) || errors.Is(err, sql.ErrNoRows) {
+		return true
+	}
+	log.Errorf("Couldn't double-check the %s stamp: %s", name, err)
+	return false
+}
+
+// reconcileStamp catches the changes that never came through gRPC, a newer
+// row moves the stamp to its time, and since stamps can't go backwards a
+// removed newest row moves the removal stamp to the current time instead
+func reconcileStamp(name string, removalName string, dbStamp int64) {
+	last, seen := observedStamps[name]
+	observedStamps[name] = dbStamp
+
+	if dbStamp > stamps.get(name) {
+		stamps.update(name, dbStamp, "Double-checked the database")
+	} else if seen && dbStamp < last {
+		stamps.update(removalName, time.Now().UnixMilli(), "The newest row got removed from the database")
+	}
+}
+
+func checkStamps(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"phrases":   stamps.phrases(),
+		"nukes":     stamps.get(stampNukes),
+		"mutelinks": stamps.get(stampMutelinks),
+		"embeds":    stamps.get(stampEmbeds),
+	})
+}
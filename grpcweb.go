@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+// grpcWebExposedHeaders need to be readable from the browser for
+// trailers-only responses, where the status ends up in the headers
+const grpcWebExposedHeaders = "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin"
+
+var grpcWeb *grpcweb.WrappedGrpcServer
+
+func newGRPCWeb() *grpcweb.WrappedGrpcServer {
+	return grpcweb.WrapServer(grpcServer,
+		// runtimeCORS sets every CORS header, so grpc-web's own layer only
+		// lets same-origin requests through where it adds nothing
+		grpcweb.WithOriginFunc(func(origin string) bool { return false }),
+		grpcweb.WithCorsForRegisteredEndpointsOnly(false),
+	)
+}
+
+// streamingResponseWriter hands the response of a net/http handler over to
+// fasthttp piece by piece, fiber's adaptor would buffer the whole response
+// and that never finishes for server-streaming RPCs like Subscribe
+type streamingResponseWriter struct {
+	ctx     context.Context
+	header  http.Header
+	status  int
+	headers http.Header
+	once    sync.Once
+	ready   chan struct{}
+	chunks  chan []byte
+}
+
+func newStreamingResponseWriter(ctx context.Context) *streamingResponseWriter {
+	return &streamingResponseWriter{
+		ctx:    ctx,
+		header: make(http.Header),
+		ready:  make(chan struct{}),
+		chunks: make(chan []byte, 16),
+	}
+}
+
+func (w *streamingResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *streamingResponseWriter) WriteHeader(status int) {
+	w.once.Do(func() {
+		w.status = status
+		w.headers = w.header.Clone()
+		close(w.ready)
+	})
+}
+
+func (w *streamingResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	chunk := make([]byte, len(b))
+	copy(chunk, b)
+	select {
+	case w.chunks <- chunk:
+		return len(b), nil
+	case <-w.ctx.Done():
+		return 0, w.ctx.Err()
+	}
+}
+
+func (w *streamingResponseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (w *streamingResponseWriter) finish() {
+	w.WriteHeader(http.StatusOK)
+	close(w.chunks)
+}
+
+func grpcWebHandler(c *fiber.Ctx) error {
+	req := &http.Request{}
+	if err := fasthttpadaptor.ConvertRequest(c.Context(), req, true); err != nil {
+		log.FiberErrorf(c, err, "gRPC-Web request conversion error")
+		return c.SendStatus(500)
+	}
+	req.URL.Path = strings.TrimPrefix(req.URL.Path, cfg.APIPrefix)
+	req.RequestURI = req.URL.RequestURI()
+	req.Body = io.NopCloser(bytes.NewReader(append([]byte(nil), c.Body()...)))
+
+	if !grpcWeb.IsGrpcWebRequest(req) {
+		return c.Status(415).SendString("Expected a gRPC-Web request")
+	}
+	// the ingestion RPCs rely on the gRPC port's TLS and client
+	// certificates, so the browser only gets the read RPCs
+	if strings.HasPrefix(req.URL.Path, ingestionMethods) {
+		return c.Status(403).SendString("The ingestion RPCs are only served on the gRPC port")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req = req.WithContext(ctx)
+
+	w := newStreamingResponseWriter(ctx)
+	go func() {
+		defer w.finish()
+		grpcWeb.ServeHTTP(w, req)
+	}()
+	<-w.ready
+
+	// runtimeCORS owns the CORS headers, grpc-web would overwrite the exposed
+	// ones with its own list, the rest replace what the middlewares set, like Vary
+	for key, values := range w.headers {
+		if strings.HasPrefix(key, "Access-Control-") {
+			continue
+		}
+		c.Response().Header.Del(key)
+		for _, value := range values {
+			c.Response().Header.Add(key, value)
+		}
+	}
+	c.Status(w.status)
+	// get the headers out even if the first message takes a while
+	c.Response().ImmediateHeaderFlush = true
+
+	c.Context().SetBodyStreamWriter(func(bw *bufio.Writer) {
+		// cancelling stops the RPC once the client goes away
+		defer cancel()
+		for chunk := range w.chunks {
+			if _, err := bw.Write(chunk); err != nil {
+				return
+			}
+			if err := bw.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
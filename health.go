@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// readyTimeout bounds every dependency check, they run concurrently
+// so /readyz answers within it even when several are hanging
+const readyTimeout = 2 * time.Second
+
+type dependencyCheck struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// readinessChecks pings every configured store and the gRPC listener,
+// a store that isn't configured is reported as disabled and doesn't
+// make the server unready since its routes already answer with a 503
+func readinessChecks() map[string]func(ctx context.Context) error {
+	checks := map[string]func(ctx context.Context) error{
+		"grpc": pingGRPC,
+	}
+
+	if storeAvailable(storePostgres) {
+		checks[storePostgres] = pg.Ping
+	}
+	if storeAvailable(storeRedis) {
+		checks[storeRedis] = func(ctx context.Context) error {
+			return rdb.Ping(ctx).Err()
+		}
+	}
+
+	sqliteStores := map[string]*sql.DB{
+		storeFeatures:   featdb,
+		storeLWOD:       lwoddb,
+		storeYTVods:     ytvoddb,
+		storeRumble:     rumbledb,
+		storeOmnimirror: omnimirrordb,
+		storeEmbeds:     embeddb,
+	}
+	for store, db := range sqliteStores {
+		if storeAvailable(store) {
+			checks[store] = db.PingContext
+		}
+	}
+
+	return checks
+}
+
+func pingGRPC(ctx context.Context) error {
+	addr := grpcAddr.Load()
+	if addr == nil {
+		return errors.New("the gRPC listener isn't up")
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort("localhost", strconv.Itoa(addr.Port)))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func getHealthz(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"status": "ok",
+	})
+}
+
+func getReadyz(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), readyTimeout)
+	defer cancel()
+
+	results := map[string]dependencyCheck{}
+	for _, store := range []string{storePostgres, storeRedis, storeFeatures, storeLWOD, storeYTVods, storeRumble, storeOmnimirror, storeEmbeds} {
+		results[store] = dependencyCheck{Status: "disabled"}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ready := true
+	for name, check := range readinessChecks() {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+			start := time.Now()
+			err := check(ctx)
+			result := dependencyCheck{
+				Status:  "ok",
+				Latency: time.Since(start).String(),
+			}
+			if err != nil {
+				result.Status = "error"
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = result
+			if err != nil {
+				ready = false
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	status := "ready"
+	// a server that's shutting down has to drop out of the load balancer
+	// before its listeners close, shutdown waits SHUTDOWN_DRAIN_DELAY for it
+	if serverCtx.Err() != nil {
+		ready = false
+		status = "shutting down"
+	} else if !ready {
+		status = "unavailable"
+	}
+
+	code := fiber.StatusOK
+	if !ready {
+		code = fiber.StatusServiceUnavailable
+	}
+	return c.Status(code).JSON(fiber.Map{
+		"status":       status,
+		"dependencies": results,
+	})
+}
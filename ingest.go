@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	log "github.com/vyneer/vyneer-api/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const seenEventsKey = "vyneer-api:events:seen:"
+
+// ingestible is implemented by every message the Receive RPCs take
+type ingestible interface {
+	GetTime() *timestamppb.Timestamp
+	GetId() string
+	GetSource() string
+}
+
+// eventLog remembers the event IDs ingested within the dedupe window and the newest
+// event time per type and source, a producer's retries can then be dropped
+// and a delayed event can't be applied after a newer one
+type eventLog struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	newest map[string]int64
+}
+
+var ingested = &eventLog{
+	seen:   make(map[string]time.Time),
+	newest: make(map[string]int64),
+}
+
+// markSeen returns false if the ID was already ingested within the window,
+// Redis makes the check work across replicas and the map covers Redis outages
+func (l *eventLog) markSeen(id string, now time.Time) bool {
+	l.mu.Lock()
+	for seenID, at := range l.seen {
+		if now.Sub(at) > cfg.EventDedupeWindow {
+			delete(l.seen, seenID)
+		}
+	}
+	_, duplicate := l.seen[id]
+	l.seen[id] = now
+	l.mu.Unlock()
+
+	if duplicate || rdb == nil {
+		return !duplicate
+	}
+
+	fresh, err := rdb.SetNX(context.Background(), seenEventsKey+id, now.UnixMilli(), cfg.EventDedupeWindow).Result()
+	if err != nil {
+		log.Errorf("Couldn't check the %s event ID in redis, relying on the local dedupe: %s", id, err)
+		return true
+	}
+	return fresh
+}
+
+func (l *eventLog) unmarkSeen(id string) {
+	l.mu.Lock()
+	delete(l.seen, id)
+	l.mu.Unlock()
+
+	if rdb == nil {
+		return
+	}
+	if err := rdb.Del(context.Background(), seenEventsKey+id).Err(); err != nil {
+		log.Errorf("Couldn't remove the %s event ID from redis: %s", id, err)
+	}
+}
+
+func orderingKey(e event) string {
+	return e.Type + "/" + e.Data.(ingestible).GetSource()
+}
+
+// tryAdvance records the event as the newest one of its type from its source
+// unless a newer one was already ingested, the check and the update happen
+// under one lock so two concurrent events can't both pass the check
+func (l *eventLog) tryAdvance(e event) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := orderingKey(e)
+	if e.Time < l.newest[key] {
+		return false
+	}
+	l.newest[key] = e.Time
+	return true
+}
+
+// observe records a fanned out event, the replica that ingested
+// it already checked the order so it's never rejected here
+func (l *eventLog) observe(e event) {
+	if _, ok := e.Data.(ingestible); !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := orderingKey(e)
+	if e.Time > l.newest[key] {
+		l.newest[key] = e.Time
+	}
+}
+
+// ingest applies an event received over gRPC locally right away
+// and fans it out to the other replicas, replays of an event ID are
+// acknowledged without applying them again
+func ingest(e event) error {
+	msg := e.Data.(ingestible)
+	if msg.GetTime() == nil {
+		return status.Error(codes.InvalidArgument, "the event time is required")
+	}
+
+	if msg.GetId() != "" && !ingested.markSeen(msg.GetId(), time.Now()) {
+		log.Infof("Ignoring a replayed %s event %s from %q", e.Type, msg.GetId(), msg.GetSource())
+		eventsIngested.WithLabelValues(e.Type, "duplicate").Inc()
+		return nil
+	}
+
+	if !ingested.tryAdvance(e) {
+		if msg.GetId() != "" {
+			ingested.unmarkSeen(msg.GetId())
+		}
+		log.Warnf("Rejected a %s event from %q, its time %+v is older than the last one", e.Type, msg.GetSource(), e.Time)
+		eventsIngested.WithLabelValues(e.Type, "out_of_order").Inc()
+		return status.Error(codes.FailedPrecondition, "the event is older than the last event of this type from this source")
+	}
+
+	e.Instance = instanceID
+	applyEvent(e)
+	publishFanout(e)
+	queueWebhooks(e)
+	eventsIngested.WithLabelValues(e.Type, "applied").Inc()
+	return nil
+}
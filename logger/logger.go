@@ -1,114 +1,222 @@
 package logger
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/apex/log"
 	"github.com/gofiber/fiber/v2"
 )
 
+const timeFormat = "2006-01-02 15:04:05.000000 MST"
+
+// Format is how the Handler writes entries
+type Format int
+
+const (
+	FormatText Format = iota
+	FormatJSON
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return FormatText, fmt.Errorf("invalid log format %q", s)
+}
+
+// Fields are the key/value pairs attached to an entry
+type Fields = log.Fields
+
+// the apex logger lets everything through to the Handler, which does the
+// filtering, apex's own level is a plain field that can't be changed safely
+// while other goroutines log
+func init() {
+	log.SetLevel(log.DebugLevel)
+}
+
 type Handler struct {
 	mu     sync.Mutex
 	Writer io.Writer
+	format Format
+	level  atomic.Int32
 }
 
 func New(w io.Writer) *Handler {
-	return &Handler{
+	h := &Handler{
 		Writer: w,
 	}
+	h.level.Store(int32(log.InfoLevel))
+	return h
+}
+
+// SetLevel drops every entry below l from now on
+func (h *Handler) SetLevel(l log.Level) {
+	h.level.Store(int32(l))
+}
+
+// SetFormat switches the format of every entry written from now on
+func (h *Handler) SetFormat(f Format) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.format = f
 }
 
 // HandleLog implements log.Handler.
 func (h *Handler) HandleLog(e *log.Entry) error {
-	names := e.Fields.Names()
+	if e.Level < log.Level(h.level.Load()) {
+		return nil
+	}
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	fmt.Fprintf(h.Writer, "%-25s", e.Message)
+	if h.format == FormatJSON {
+		return h.writeJSON(e)
+	}
+	return h.writeText(e)
+}
 
-	for _, name := range names {
-		fmt.Fprintf(h.Writer, "%v", e.Fields.Get(name))
+// writeText writes "[time] LEVEL message key=value ...",
+// values with spaces or quotes in them get quoted
+func (h *Handler) writeText(e *log.Entry) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %-5s %s", e.Timestamp.Format(timeFormat), strings.ToUpper(e.Level.String()), e.Message)
+
+	for _, name := range e.Fields.Names() {
+		value := fmt.Sprint(e.Fields.Get(name))
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&b, " %s=%s", name, value)
 	}
+	b.WriteByte('\n')
+
+	_, err := io.WriteString(h.Writer, b.String())
+	return err
+}
 
-	fmt.Fprintln(h.Writer)
+// writeJSON writes one object per line, durations are
+// written in milliseconds and errors as their message
+func (h *Handler) writeJSON(e *log.Entry) error {
+	entry := make(map[string]interface{}, len(e.Fields)+3)
+	for name, value := range e.Fields {
+		switch v := value.(type) {
+		case time.Duration:
+			entry[name] = float64(v) / float64(time.Millisecond)
+		case error:
+			entry[name] = v.Error()
+		default:
+			entry[name] = v
+		}
+	}
+	entry["time"] = e.Timestamp.Format(time.RFC3339Nano)
+	entry["level"] = e.Level.String()
+	entry["msg"] = e.Message
 
-	return nil
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.Writer.Write(append(line, '\n'))
+	return err
 }
 
-func SetLevel(l log.Level) {
-	log.SetLevel(l)
+func ParseLevel(s string) (log.Level, error) {
+	return log.ParseLevel(s)
 }
 
 func SetHandler(h log.Handler) {
 	log.SetHandler(h)
 }
 
+func WithFields(fields Fields) *log.Entry {
+	return log.WithFields(fields)
+}
+
+// fiberEntry carries the request fields, the path
+// keeps its query string since handlers depend on it
+func fiberEntry(c *fiber.Ctx, err error) *log.Entry {
+	entry := log.WithFields(Fields{
+		"method": c.Method(),
+		"path":   c.OriginalURL(),
+		"ip":     c.IP(),
+	})
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+	return entry
+}
+
+// AccessLog logs every request once it's been handled, server errors
+// are logged as errors and everything else as info
+func AccessLog(c *fiber.Ctx) error {
+	start := time.Now()
+	err := c.Next()
+
+	status := c.Response().StatusCode()
+	if err != nil {
+		status = fiber.StatusInternalServerError
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			status = fiberErr.Code
+		}
+	}
+
+	entry := fiberEntry(c, err).WithFields(Fields{
+		"status":  status,
+		"latency": time.Since(start),
+	})
+	if status >= fiber.StatusInternalServerError {
+		entry.Error("request")
+	} else {
+		entry.Info("request")
+	}
+	return err
+}
+
 func Debugf(str string, v ...interface{}) {
-	res := `[%s] ` + str
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Debugf(res, v...)
+	log.Debugf(str, v...)
 }
 
-func FiberDebugf(str string, c *fiber.Ctx, v ...interface{}) {
-	res := `[%s] %s -  XXX   %s      %s - ` + str
-	v = append([]interface{}{c.Path()}, v...)
-	v = append([]interface{}{c.Method()}, v...)
-	v = append([]interface{}{c.IP()}, v...)
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Debugf(res, v...)
+func FiberDebugf(c *fiber.Ctx, err error, str string, v ...interface{}) {
+	fiberEntry(c, err).Debugf(str, v...)
 }
 
 func Errorf(str string, v ...interface{}) {
-	res := `[%s] ` + str
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Errorf(res, v...)
+	log.Errorf(str, v...)
 }
 
-func FiberErrorf(str string, c *fiber.Ctx, v ...interface{}) {
-	res := `[%s] %s -  XXX   %s      %s - ` + str
-	v = append([]interface{}{c.Path()}, v...)
-	v = append([]interface{}{c.Method()}, v...)
-	v = append([]interface{}{c.IP()}, v...)
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Errorf(res, v...)
+func FiberErrorf(c *fiber.Ctx, err error, str string, v ...interface{}) {
+	fiberEntry(c, err).Errorf(str, v...)
 }
 
 func Fatalf(str string, v ...interface{}) {
-	res := `[%s] ` + str
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Fatalf(res, v...)
+	log.Fatalf(str, v...)
 }
 
-func FiberFatalf(str string, c *fiber.Ctx, v ...interface{}) {
-	res := `[%s] %s -  XXX   %s      %s - ` + str
-	v = append([]interface{}{c.Path()}, v...)
-	v = append([]interface{}{c.Method()}, v...)
-	v = append([]interface{}{c.IP()}, v...)
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Fatalf(res, v...)
+func FiberFatalf(c *fiber.Ctx, err error, str string, v ...interface{}) {
+	fiberEntry(c, err).Fatalf(str, v...)
 }
 
 func Infof(str string, v ...interface{}) {
-	res := `[%s] ` + str
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Infof(res, v...)
+	log.Infof(str, v...)
 }
 
-func FiberInfof(str string, c *fiber.Ctx, v ...interface{}) {
-	res := `[%s] %s -  XXX   %s      %s - ` + str
-	v = append([]interface{}{c.Path()}, v...)
-	v = append([]interface{}{c.Method()}, v...)
-	v = append([]interface{}{c.IP()}, v...)
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Infof(res, v...)
+func FiberInfof(c *fiber.Ctx, err error, str string, v ...interface{}) {
+	fiberEntry(c, err).Infof(str, v...)
 }
 
 func Warnf(str string, v ...interface{}) {
-	res := `[%s] ` + str
-	v = append([]interface{}{time.Now().Format("2006-01-02 15:04:05.000000 MST")}, v...)
-	log.Warnf(res, v...)
+	log.Warnf(str, v...)
 }
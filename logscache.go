@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+const logsCachePrefix = "vyneer-api:logs:"
+
+// logsCacheGrace gives late log lines a chance to land
+// before a range that just ended is considered final
+const logsCacheGrace = time.Minute
+
+// maxZoneOffset is how far ahead of UTC a time zone can be, a timestamp
+// without a zone is read in pg's time zone so we assume the worst one
+const maxZoneOffset = time.Hour * 14
+
+var zonedLayouts = []string{time.RFC3339Nano}
+var zonelessLayouts = []string{"2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999", "2006-01-02"}
+
+// logsRangeEnd figures out the latest moment the to parameter can refer to,
+// ok is false when it's not in a format we can be sure about
+func logsRangeEnd(to string) (time.Time, bool) {
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, to); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range zonelessLayouts {
+		if t, err := time.Parse(layout, to); err == nil {
+			return t.Add(maxZoneOffset), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// logsFinal tells whether the range is fully in the past,
+// the logs of a range like that never change again
+func logsFinal(to string) bool {
+	end, ok := logsRangeEnd(to)
+	return ok && end.Add(logsCacheGrace).Before(time.Now())
+}
+
+// cachedLogs returns the JSON of the logs between from and to, finalized
+// ranges are served from and stored in redis, anything else goes to pg
+func cachedLogs(from string, to string) ([]byte, bool, error) {
+	final := logsFinal(to) && rdb != nil
+	key := logsCachePrefix + from + "|" + to
+
+	if final {
+		body, err := rdb.Get(context.Background(), key).Bytes()
+		switch {
+		case err == nil:
+			return body, true, nil
+		case !errors.Is(err, redis.Nil):
+			log.Errorf("Couldn't get the cached logs from redis: %s", err)
+		}
+	}
+
+	body, err := coalesce("logs?from="+from+"&to="+to, func() ([]byte, error) {
+		data, err := logs(from, to)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(data)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if final && len(body) <= cfg.LogsCache.MaxBytes {
+		if err := rdb.Set(context.Background(), key, body, cfg.LogsCache.TTL).Err(); err != nil {
+			log.Errorf("Couldn't cache the logs in redis: %s", err)
+		}
+	}
+
+	return body, false, nil
+}
+
+func getLogs(c *fiber.Ctx) error {
+	from := c.Query("from")
+	to := c.Query("to")
+
+	if from == "" || to == "" {
+		return c.JSON(fiber.Map{})
+	}
+
+	body, hit, err := cachedLogs(from, to)
+	if err != nil {
+		log.FiberErrorf(c, err, "Postgres query error")
+		return c.SendStatus(500)
+	}
+
+	if hit {
+		c.Set("X-Cache", "HIT")
+	} else {
+		c.Set("X-Cache", "MISS")
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(body)
+}
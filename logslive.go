@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+const logsNotifyChannel = "vyneer_api_logs"
+
+var liveLogs = newBroker[logLineString](256)
+
+// logsNotifyInstalled checks for the trigger from migrations/0001_logs_notify.sql,
+// the API never installs it itself since that's a schema change on the scraper's table
+func logsNotifyInstalled() (bool, error) {
+	var exists bool
+	err := pg.QueryRow(context.Background(), "select exists(select 1 from pg_trigger where tgname = 'vyneer_api_notify_logs')").Scan(&exists)
+	return exists, err
+}
+
+// logsNotification is a logs row as the trigger sends it, rows too
+// big for a notification only carry the key to read them back with
+type logsNotification struct {
+	logLineString
+	At        string `json:"at"`
+	Truncated bool   `json:"truncated"`
+}
+
+func listenLogs(ctx context.Context) {
+	installed, err := logsNotifyInstalled()
+	if err != nil {
+		log.Warnf("Couldn't check for the logs notify trigger: %s", err)
+	} else if !installed {
+		log.Warnf("The logs notify trigger isn't installed, /logs/live won't get any lines until migrations/0001_logs_notify.sql is applied")
+	}
+
+	for {
+		err := listenLogsOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Errorf("Lost the %s Postgres listener, reconnecting in 5 seconds: %s", logsNotifyChannel, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
+	}
+}
+
+func listenLogsOnce(ctx context.Context) error {
+	conn, err := pg.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "LISTEN "+logsNotifyChannel)
+	if err != nil {
+		return err
+	}
+	// the connection goes back to the pool, so it shouldn't keep listening
+	defer conn.Exec(context.Background(), "UNLISTEN "+logsNotifyChannel)
+
+	log.Infof("Listening for new logs on the %s Postgres channel", logsNotifyChannel)
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		n := logsNotification{}
+		if err := json.Unmarshal([]byte(notification.Payload), &n); err != nil {
+			log.Errorf("Couldn't unmarshal a logs notification: %s", err)
+			continue
+		}
+		p := n.logLineString
+		if n.Truncated {
+			// the listening connection is busy, so this goes through the pool
+			err := pg.QueryRow(ctx, "SELECT features, message FROM logs WHERE time = $1 AND username = $2 LIMIT 1", n.At, n.Username).Scan(&p.Features, &p.Message)
+			if err != nil {
+				log.Errorf("Couldn't read back a logs row too big for a notification: %s", err)
+				continue
+			}
+		}
+		liveLogs.publish(p)
+	}
+}
+
+func getLiveLogs(c *fiber.Ctx) error {
+	usernames := make(map[string]bool)
+	if c.Query("username") != "" {
+		for _, username := range strings.Split(c.Query("username"), ",") {
+			usernames[strings.ToLower(username)] = true
+		}
+	}
+	features := []string{}
+	if c.Query("features") != "" {
+		features = strings.Split(c.Query("features"), ",")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ch := liveLogs.subscribe()
+		defer liveLogs.unsubscribe(ch)
+
+		keepAlive := time.NewTicker(time.Second * 15)
+		defer keepAlive.Stop()
+
+		fmt.Fprintf(w, "retry: 5000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case <-serverCtx.Done():
+				return
+			case line := <-ch:
+				if !matchesLogFilters(line, usernames, features) {
+					continue
+				}
+				data, err := json.Marshal(line)
+				if err != nil {
+					log.Errorf("Couldn't marshal a log line: %s", err)
+					continue
+				}
+				fmt.Fprintf(w, "event: log\ndata: %s\n\n", data)
+			case <-keepAlive.C:
+				fmt.Fprintf(w, ": keepalive\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// matchesLogFilters checks the username case-insensitively against any
+// of the requested ones, and requires every requested feature to be present
+func matchesLogFilters(line logLineString, usernames map[string]bool, features []string) bool {
+	if len(usernames) > 0 && !usernames[strings.ToLower(line.Username)] {
+		return false
+	}
+	for _, feature := range features {
+		if !strings.Contains(line.Features, feature) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,39 +1,29 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 	_ "time/tzdata"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/limiter"
-	fiberLogger "github.com/gofiber/fiber/v2/middleware/logger"
-	"github.com/jackc/pgtype"
+	"github.com/gofiber/websocket/v2"
 	"github.com/jackc/pgx/v4/pgxpool"
-	"github.com/joho/godotenv"
 	_ "github.com/mattn/go-sqlite3"
 	log "github.com/vyneer/vyneer-api/logger"
 )
 
-var trustedProxyIP string
-var pgUser string
-var pgPass string
-var pgHost string
-var pgPort string
-var pgName string
-var redisHost string
-var redisPort string
-var redisPass string
-
 var featdb *sql.DB
 var lwoddb *sql.DB
 var ytvoddb *sql.DB
@@ -47,20 +37,22 @@ var nukeRegex *regexp.Regexp
 var regexCheck *regexp.Regexp
 var mutelinksRegex *regexp.Regexp
 
+var logHandler = log.New(os.Stderr)
+
 func init() {
-	log.SetHandler(log.New((os.Stderr)))
+	log.SetHandler(logHandler)
 }
 
 func getScript(c *fiber.Ctx) error {
 	if c.Params("dev") == "" {
 		scriptVersion, err := rdb.Get(context.Background(), "SCRIPT_VERSION").Result()
 		if err != nil {
-			log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "redis query error")
 			return c.SendStatus(500)
 		}
 		scriptLink, err := rdb.Get(context.Background(), "SCRIPT_LINK").Result()
 		if err != nil {
-			log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "redis query error")
 			return c.SendStatus(500)
 		}
 		return c.JSON(&fiber.Map{
@@ -70,12 +62,12 @@ func getScript(c *fiber.Ctx) error {
 	} else {
 		devScriptVersion, err := rdb.Get(context.Background(), "DEV_SCRIPT_VERSION").Result()
 		if err != nil {
-			log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "redis query error")
 			return c.SendStatus(500)
 		}
 		devScriptLink, err := rdb.Get(context.Background(), "DEV_SCRIPT_LINK").Result()
 		if err != nil {
-			log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "redis query error")
 			return c.SendStatus(500)
 		}
 		return c.JSON(&fiber.Map{
@@ -87,156 +79,68 @@ func getScript(c *fiber.Ctx) error {
 }
 
 func getFeatures(c *fiber.Ctx) error {
-	feats := []feature{}
-	var featsFormatted map[string]string = make(map[string]string)
-
-	rows, err := featdb.Query("SELECT * from dggfeat")
-	if err != nil {
-		log.Errorf("%s %s - featdb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+	features, ok := snapshotData(c, featuresSnapshot)
+	if !ok {
 		return c.SendStatus(500)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		p := feature{}
-		err := rows.Scan(&p.Username, &p.Feat)
-		if err != nil {
-			log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			continue
-		}
-		feats = append(feats, p)
-	}
-
-	for _, feat := range feats {
-		featsFormatted[feat.Username] = feat.Feat
-	}
-
-	return c.JSON(featsFormatted)
+	return c.JSON(features)
 }
 
 func getYTvods(c *fiber.Ctx) error {
-	ytvods := []ytvod{}
-
-	rows, err := ytvoddb.Query("SELECT vodid, title, starttime, endtime, thumbnail from ytvods ORDER BY datetime(starttime) DESC LIMIT 45")
-	if err != nil {
-		log.Errorf("%s %s - ytvoddb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+	ytvods, ok := snapshotData(c, ytvodsSnapshot)
+	if !ok {
 		return c.SendStatus(500)
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		p := ytvod{}
-		err := rows.Scan(&p.ID, &p.Title, &p.Start, &p.End, &p.Thumbnail)
-		if err != nil {
-			log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			continue
-		}
-		ytvods = append(ytvods, p)
-	}
 
 	return c.JSON(ytvods)
 }
 
 func getRumbleVods(c *fiber.Ctx) error {
-	rumblevods := []rumblevod{}
-
-	rows, err := rumbledb.Query("SELECT public_id, embed_id, title, link, thumbnail, start_time, end_time from rumble ORDER BY datetime(start_time) DESC LIMIT 45")
-	if err != nil {
-		log.Errorf("%s %s - rumbledb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+	rumblevods, ok := snapshotData(c, rumbleSnapshot)
+	if !ok {
 		return c.SendStatus(500)
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		p := rumblevod{}
-		err := rows.Scan(&p.PublicID, &p.EmbedID, &p.Title, &p.Link, &p.Thumbnail, &p.Start, &p.End)
-		if err != nil {
-			log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			continue
-		}
-		rumblevods = append(rumblevods, p)
-	}
 
 	return c.JSON(rumblevods)
 }
 
 func getOmnimirrorVods(c *fiber.Ctx) error {
-	rumblevods := []rumblevod{}
-
-	rows, err := omnimirrordb.Query("SELECT public_id, embed_id, title, link, thumbnail, start_time, end_time from rumble ORDER BY datetime(start_time) DESC LIMIT 45")
-	if err != nil {
-		log.Errorf("%s %s - omnimirrordb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+	rumblevods, ok := snapshotData(c, omnimirrorSnapshot)
+	if !ok {
 		return c.SendStatus(500)
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		p := rumblevod{}
-		err := rows.Scan(&p.PublicID, &p.EmbedID, &p.Title, &p.Link, &p.Thumbnail, &p.Start, &p.End)
-		if err != nil {
-			log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			continue
-		}
-		rumblevods = append(rumblevods, p)
-	}
 
 	return c.JSON(rumblevods)
 }
 
 func getEmbeds(c *fiber.Ctx) error {
 	timeString := c.Query("t")
-	embeds := []embed{}
-	lastembeds := []lastembed{}
 
 	if c.Params("last") == "" {
-		if timeString != "" {
-			timeInt, err := strconv.Atoi(timeString)
-			if err != nil {
-				log.Errorf("%s %s - String to int conversion error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-				return c.Status(500).SendString("The time parameter is invalid")
-			}
-			if timeInt >= 5 && timeInt <= 60 {
-				rows, err := embeddb.Query("select link, platform, channel, title, count(link) as freq from embeds where timest >= strftime('%s', 'now') - $1 group by link order by freq desc limit 5", timeInt*60)
-				if err != nil {
-					log.Errorf("%s %s - embeddb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-					return c.SendStatus(500)
-				}
-				defer rows.Close()
-
-				for rows.Next() {
-					p := embed{}
-					err := rows.Scan(&p.Link, &p.Platform, &p.Channel, &p.Title, &p.Count)
-					if err != nil {
-						log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-						continue
-					}
-					embeds = append(embeds, p)
-				}
-			} else {
-				return c.Status(400).SendString("Time needs to be between 5 and 60 minutes")
-			}
-		} else {
+		if timeString == "" {
 			return c.Status(400).SendString("The time parameter has not been provided")
 		}
+		timeInt, err := strconv.Atoi(timeString)
+		if err != nil {
+			log.FiberErrorf(c, err, "String to int conversion error")
+			return c.Status(500).SendString("The time parameter is invalid")
+		}
+		if timeInt < 5 || timeInt > 60 {
+			return c.Status(400).SendString("Time needs to be between 5 and 60 minutes")
+		}
+		embeds, err := embeds(timeInt)
+		if err != nil {
+			log.FiberErrorf(c, err, "embeddb query error")
+			return c.SendStatus(500)
+		}
 		return c.JSON(embeds)
 	} else {
-		rows, err := embeddb.Query("select timest, link, platform, channel, title from embeds order by timest desc limit 5")
+		lastembeds, err := lastEmbeds()
 		if err != nil {
-			log.Errorf("%s %s - embeddb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "embeddb query error")
 			return c.SendStatus(500)
 		}
-		defer rows.Close()
-
-		for rows.Next() {
-			p := lastembed{}
-			err := rows.Scan(&p.Timestamp, &p.Link, &p.Platform, &p.Channel, &p.Title)
-			if err != nil {
-				log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-				continue
-			}
-			lastembeds = append(lastembeds, p)
-		}
-
 		return c.JSON(lastembeds)
 	}
 }
@@ -247,131 +151,141 @@ func getPhrases(c *fiber.Ctx) error {
 	if err != nil {
 		switch {
 		case errors.Is(err, strconv.ErrSyntax):
-			log.Errorf("%s %s - String to int conversion error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "String to int conversion error")
 			return c.Status(500).SendString("The count parameter is invalid")
 		default:
-			log.Errorf("%s %s - Phrases error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "Phrases error")
 			c.SendStatus(500)
 		}
-		log.Errorf("%s %s - String to int conversion error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "String to int conversion error")
 		return c.Status(500).SendString("The count parameter is invalid")
 	}
 
 	if c.Query("ts") == "1" {
-		if phraseStamp >= phraseRemovalStamp {
-			return c.JSON(fiber.Map{
-				"updatedAt": phraseStamp,
-				"data":      phrases,
-			})
-		} else {
-			return c.JSON(fiber.Map{
-				"updatedAt": phraseRemovalStamp,
-				"data":      phrases,
-			})
-		}
+		return c.JSON(fiber.Map{
+			"updatedAt": stamps.phrases(),
+			"data":      phrases,
+		})
 	} else {
 		return c.JSON(phrases)
 	}
 }
 
-func getLWOD(c *fiber.Ctx) error {
-	vodid := c.Query("id")
-	vidid := c.Query("v")
-
-	twitchEntries := []lwodTwitch{}
-	youtubeEntries := []lwodYT{}
-	allEntries := []lwod{}
+func getPhrasesExport(c *fiber.Ctx) error {
+	phrases, err := phrases("")
+	if err != nil {
+		log.FiberErrorf(c, err, "Phrases error")
+		return c.SendStatus(500)
+	}
 
-	if vodid != "" {
-		rows, err := lwoddb.Query("SELECT starttime, endtime, game, subject, topic from lwod WHERE vodid=$1", vodid)
-		if err != nil {
-			log.Errorf("%s %s - lwoddb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			return c.SendStatus(500)
+	switch c.Query("format", "json") {
+	case "json":
+		c.Attachment("phrases.json")
+		return c.JSON(phrases)
+	case "csv":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write(phraseCSVHeader)
+		for _, p := range phrases {
+			w.Write([]string{p.Time.Format(time.RFC3339Nano), p.Username, p.Phrase, p.Duration, p.Type})
 		}
-		defer rows.Close()
-
-		for rows.Next() {
-			p := lwodTwitch{}
-			err := rows.Scan(&p.Start, &p.End, &p.Game, &p.Subject, &p.Topic)
-			if err != nil {
-				log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-				continue
-			}
-			twitchEntries = append(twitchEntries, p)
+		w.Flush()
+		if err := w.Error(); err != nil {
+			log.FiberErrorf(c, err, "CSV write error")
+			return c.SendStatus(500)
 		}
+		c.Attachment("phrases.csv")
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return c.Send(buf.Bytes())
+	default:
+		return c.Status(400).SendString("The format parameter needs to be either json or csv")
+	}
+}
 
-		return c.JSON(twitchEntries)
-	} else if vidid != "" {
-		rows, err := lwoddb.Query("SELECT yttime, game, subject, topic from lwod WHERE vidid=$1 ORDER by yttime", vidid)
+func postPhrasesImport(c *fiber.Ctx) error {
+	incoming := []phrase{}
+
+	if c.Is("csv") || c.Query("format") == "csv" {
+		records, err := csv.NewReader(bytes.NewReader(c.Body())).ReadAll()
 		if err != nil {
-			log.Errorf("%s %s - lwoddb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			return c.SendStatus(500)
+			return c.Status(400).SendString(fmt.Sprintf("Couldn't parse the CSV body: %s", err))
 		}
-		defer rows.Close()
-
-		for rows.Next() {
-			p := lwodYT{}
-			err := rows.Scan(&p.Time, &p.Game, &p.Subject, &p.Topic)
-			if err != nil {
-				log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-				continue
-			}
-			youtubeEntries = append(youtubeEntries, p)
+		if len(records) == 0 {
+			return c.Status(400).SendString("The CSV body is empty")
 		}
-
-		return c.JSON(youtubeEntries)
-	} else {
-		rows, err := lwoddb.Query("SELECT vodid, vidid, starttime, endtime, game, subject, topic from lwod")
-		if err != nil {
-			log.Errorf("%s %s - lwoddb query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			return c.SendStatus(500)
+		if !isPhraseCSVHeader(records[0]) {
+			return c.Status(400).SendString(fmt.Sprintf("The first CSV row needs to be the header %q", strings.Join(phraseCSVHeader, ",")))
 		}
-		defer rows.Close()
-
-		for rows.Next() {
-			p := lwod{}
-			err := rows.Scan(&p.Twitch, &p.YouTube, &p.Start, &p.End, &p.Game, &p.Subject, &p.Topic)
-			if err != nil {
-				log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-				continue
+		for i, record := range records[1:] {
+			// the header is row 1 of the file
+			row := i + 2
+			if len(record) != len(phraseCSVHeader) {
+				return c.Status(400).SendString(fmt.Sprintf("Row %d has %d columns, expected %d", row, len(record), len(phraseCSVHeader)))
+			}
+			p := phrase{
+				Username: record[1],
+				Phrase:   record[2],
+				Duration: record[3],
+				Type:     record[4],
+			}
+			if record[0] != "" {
+				p.Time, err = time.Parse(time.RFC3339Nano, record[0])
+				if err != nil {
+					return c.Status(400).SendString(fmt.Sprintf("Row %d has an invalid time: %s", row, err))
+				}
 			}
-			allEntries = append(allEntries, p)
+			incoming = append(incoming, p)
 		}
+	} else {
+		if err := json.Unmarshal(c.Body(), &incoming); err != nil {
+			return c.Status(400).SendString(fmt.Sprintf("Couldn't parse the JSON body: %s", err))
+		}
+	}
 
-		return c.JSON(allEntries)
+	report, err := observe(storePostgres, "phrasesimport", func() (phraseImportReport, error) {
+		return importPhrases(incoming, c.Query("overwrite") == "1", c.Query("dryrun") == "1")
+	})
+	if err != nil {
+		log.FiberErrorf(c, err, "Phrase import error")
+		return c.SendStatus(500)
+	}
+	if len(report.Invalid) > 0 {
+		return c.Status(400).JSON(report)
+	}
+
+	if !report.DryRun && report.Imported+report.Replaced > 0 {
+		// an import of older phrases wouldn't move the phrases stamp,
+		// so we bump the removal stamp to make sure clients notice the change
+		bumpPhrases(fmt.Sprintf("Imported %d phrases (%d replaced)", report.Imported, report.Replaced))
 	}
+
+	return c.JSON(report)
 }
 
-func getLogs(c *fiber.Ctx) error {
-	from := c.Query("from")
-	to := c.Query("to")
-	logsRaw := []logGroup{}
-	var logs map[int64][]pgtype.JSON = make(map[int64][]pgtype.JSON)
+func getLWOD(c *fiber.Ctx) error {
+	vodid := c.Query("id")
+	vidid := c.Query("v")
 
-	if from != "" && to != "" {
-		rows, err := pg.Query(context.Background(), "SELECT extract(epoch from date_trunc('second', time)), array_agg(json_build_object('username', username, 'features', features, 'message', message)) FROM logs WHERE time >= $1 AND time < $2 GROUP BY date_trunc('second', time) ORDER BY date_trunc('second', time)", from, to)
-		if err != nil {
-			log.Errorf("%s %s - Postgres query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			return c.SendStatus(500)
-		}
-		defer rows.Close()
-
-		for rows.Next() {
-			p := logGroup{}
-			err := rows.Scan(&p.Time, &p.Lines)
-			if err != nil {
-				log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-				continue
-			}
-			logsRaw = append(logsRaw, p)
-		}
+	dataset, ok := snapshotData(c, lwodSnapshot)
+	if !ok {
+		return c.SendStatus(500)
+	}
 
-		for _, group := range logsRaw {
-			logs[group.Time] = group.Lines.Elements
+	if vodid != "" {
+		twitchEntries, ok := dataset.twitch[vodid]
+		if !ok {
+			twitchEntries = []lwodTwitch{}
+		}
+		return c.JSON(twitchEntries)
+	} else if vidid != "" {
+		youtubeEntries, ok := dataset.youtube[vidid]
+		if !ok {
+			youtubeEntries = []lwodYT{}
 		}
+		return c.JSON(youtubeEntries)
+	} else {
+		return c.JSON(dataset.all)
 	}
-
-	return c.JSON(logs)
 }
 
 func getRawLogs(c *fiber.Ctx) error {
@@ -380,22 +294,14 @@ func getRawLogs(c *fiber.Ctx) error {
 	logs := []logLineString{}
 
 	if from != "" && to != "" {
-		rows, err := pg.Query(context.Background(), "SELECT to_char(time, 'YYYY-MM-DD\"T\"HH24:MI:SS.MSZ'), username, features, message FROM logs WHERE time >= $1 AND time < $2 ORDER BY time", from, to)
+		err := rawLogs(from, to, func(p logLineString) error {
+			logs = append(logs, p)
+			return nil
+		})
 		if err != nil {
-			log.Errorf("%s %s - Postgres query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+			log.FiberErrorf(c, err, "Postgres query error")
 			return c.SendStatus(500)
 		}
-		defer rows.Close()
-
-		for rows.Next() {
-			p := logLineString{}
-			err := rows.Scan(&p.Time, &p.Username, &p.Features, &p.Message)
-			if err != nil {
-				log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-				continue
-			}
-			logs = append(logs, p)
-		}
 	}
 
 	return c.JSON(logs)
@@ -404,13 +310,13 @@ func getRawLogs(c *fiber.Ctx) error {
 func getNukes(c *fiber.Ctx) error {
 	data, err := nukes()
 	if err != nil {
-		log.Errorf("%s %s - Nukes error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "Nukes error")
 		return c.SendStatus(500)
 	}
 
 	if c.Query("ts") == "1" {
 		return c.JSON(fiber.Map{
-			"updatedAt": nukeStamp,
+			"updatedAt": stamps.get(stampNukes),
 			"data":      data,
 		})
 	} else {
@@ -421,13 +327,13 @@ func getNukes(c *fiber.Ctx) error {
 func getMutelinks(c *fiber.Ctx) error {
 	mutelinks, err := mutelinks()
 	if err != nil {
-		log.Errorf("%s %s - Mutelinks error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "Mutelinks error")
 		return c.SendStatus(500)
 	}
 	if mutelinks != nil {
 		if c.Query("ts") == "1" {
 			return c.JSON(fiber.Map{
-				"updatedAt": mutelinksStamp,
+				"updatedAt": stamps.get(stampMutelinks),
 				"data":      mutelinks,
 			})
 		} else {
@@ -442,22 +348,13 @@ func getMsgCount(c *fiber.Ctx) error {
 	username := c.Query("u")
 	count := msgCount{}
 
-	rows, err := pg.Query(context.Background(), "select count(*) from logs where username ~* $1 and time >= current_date::timestamp and time < current_date::timestamp + interval '1 day'", username)
+	err := observeQuery(storePostgres, "msgcount", func() error {
+		return pg.QueryRow(context.Background(), "select count(*) from logs where username ~* $1 and time >= current_date::timestamp and time < current_date::timestamp + interval '1 day'", username).Scan(&count.Count)
+	})
 	if err != nil {
-		log.Errorf("%s %s - Postgres query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "Postgres query error")
 		return c.SendStatus(500)
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		p := msgCount{}
-		err := rows.Scan(&p.Count)
-		if err != nil {
-			log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
-			continue
-		}
-		count = p
-	}
 
 	return c.JSON(count)
 }
@@ -465,11 +362,11 @@ func getMsgCount(c *fiber.Ctx) error {
 func getLastLWODSheet(c *fiber.Ctx) error {
 	lastLWODSheet := lwodUrl{}
 
-	row := lwoddb.QueryRow("SELECT sheetId from lwodUrl ORDER BY datetime(date) DESC LIMIT 1")
-
-	err := row.Scan(&lastLWODSheet.ID)
+	err := observeQuery(storeLWOD, "lastlwod", func() error {
+		return lwoddb.QueryRow("SELECT sheetId from lwodUrl ORDER BY datetime(date) DESC LIMIT 1").Scan(&lastLWODSheet.ID)
+	})
 	if err != nil {
-		log.Errorf("%s %s - Query scan error: %s", c.Method(), c.Path(), err)
+		log.FiberErrorf(c, err, "Query scan error")
 		return c.SendStatus(500)
 	}
 
@@ -479,22 +376,22 @@ func getLastLWODSheet(c *fiber.Ctx) error {
 func getProviders(c *fiber.Ctx) error {
 	embedsProvider, err := rdb.Get(context.Background(), "SCRIPT_EMBEDS_PROVIDER").Result()
 	if err != nil {
-		log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "redis query error")
 		return c.SendStatus(500)
 	}
 	phrasesProvider, err := rdb.Get(context.Background(), "SCRIPT_PHRASES_PROVIDER").Result()
 	if err != nil {
-		log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "redis query error")
 		return c.SendStatus(500)
 	}
 	nukesProvider, err := rdb.Get(context.Background(), "SCRIPT_NUKES_PROVIDER").Result()
 	if err != nil {
-		log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "redis query error")
 		return c.SendStatus(500)
 	}
 	linksProvider, err := rdb.Get(context.Background(), "SCRIPT_LINKS_PROVIDER").Result()
 	if err != nil {
-		log.Errorf("%s %s - redis query error: %s", c.Method(), c.Path()+"?"+string(c.Request().URI().QueryString()), err)
+		log.FiberErrorf(c, err, "redis query error")
 		return c.SendStatus(500)
 	}
 
@@ -506,109 +403,53 @@ func getProviders(c *fiber.Ctx) error {
 	})
 }
 
-func loadDotEnv() {
-	log.Infof("Loading environment variables")
-	godotenv.Load()
-	trustedProxyIP = os.Getenv("TRUSTED_PROXY")
-	if trustedProxyIP == "" {
-		log.Fatalf("Please set the TRUSTED_PROXY environment variable and restart the server")
-	}
-	pgUser = os.Getenv("POSTGRES_USER")
-	if pgUser == "" {
-		log.Fatalf("Please set the POSTGRES_USER environment variable and restart the server")
-	}
-	pgPass = os.Getenv("POSTGRES_PASSWORD")
-	if pgPass == "" {
-		log.Fatalf("Please set the POSTGRES_PASSWORD environment variable and restart the server")
-	}
-	pgHost = os.Getenv("POSTGRES_HOST")
-	if pgHost == "" {
-		log.Fatalf("Please set the POSTGRES_HOST environment variable and restart the server")
-	}
-	pgPort = os.Getenv("POSTGRES_PORT")
-	if pgPort == "" {
-		log.Fatalf("Please set the POSTGRES_PORT environment variable and restart the server")
+func adminOnly(c *fiber.Ctx) error {
+	if cfg.AdminToken == "" {
+		return c.Status(403).SendString("Admin endpoints are disabled")
 	}
-	pgName = os.Getenv("POSTGRES_DB")
-	if pgName == "" {
-		log.Fatalf("Please set the POSTGRES_DB environment variable and restart the server")
+	token := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(cfg.AdminToken)) != 1 {
+		log.FiberErrorf(c, nil, "Unauthorized admin request")
+		return c.SendStatus(401)
 	}
-	redisHost = os.Getenv("REDIS_HOST")
-	if redisHost == "" {
-		log.Fatalf("Please set the REDIS_HOST environment variable and restart the server")
-	}
-	redisPort = os.Getenv("REDIS_PORT")
-	if redisPort == "" {
-		log.Fatalf("Please set the REDIS_PORT environment variable and restart the server")
-	}
-	redisPass = os.Getenv("REDIS_PASSWORD")
-
-	loc, err := time.LoadLocation("UTC")
-	if err != nil {
-		log.Fatalf("%s", err)
-	}
-	time.Local = loc
-
-	log.Infof("Environment variables loaded successfully")
+	return c.Next()
 }
 
 func loadDatabases() {
 	log.Infof("Connecting to databases")
-	dbpath := filepath.Join(".", "db")
-	err := os.MkdirAll(dbpath, os.ModePerm)
-	if err != nil {
-		log.Fatalf("Error creating a db directory: %s", err)
-	}
-
-	pgUrl := fmt.Sprintf("postgres://%s:%s@%s:%s/%s", pgUser, pgPass, pgHost, pgPort, pgName)
-
-	dbpath = filepath.Join(".", "db", "featdb.db")
-	featdb, err = sql.Open("sqlite3", dbpath)
-	if err != nil {
-		log.Fatalf("Error opening featdb: %s", err)
-	}
-
-	dbpath = filepath.Join(".", "db", "lwoddb.db")
-	lwoddb, err = sql.Open("sqlite3", dbpath)
-	if err != nil {
-		log.Fatalf("Error opening lwoddb: %s", err)
-	}
-
-	dbpath = filepath.Join(".", "db", "ytvoddb.db")
-	ytvoddb, err = sql.Open("sqlite3", dbpath)
-	if err != nil {
-		log.Fatalf("Error opening ytvoddb: %s", err)
-	}
-
-	dbpath = filepath.Join(".", "db", "rumble.sqlite")
-	rumbledb, err = sql.Open("sqlite3", dbpath)
-	if err != nil {
-		log.Fatalf("Error opening rumbledb: %s", err)
-	}
 
-	dbpath = filepath.Join(".", "db", "omnimirror.sqlite")
-	omnimirrordb, err = sql.Open("sqlite3", dbpath)
-	if err != nil {
-		log.Fatalf("Error opening omnimirrordb: %s", err)
-	}
-
-	dbpath = filepath.Join(".", "db", "embeddb.db")
-	embeddb, err = sql.Open("sqlite3", dbpath)
-	if err != nil {
-		log.Fatalf("Error opening embeddb: %s", err)
+	featdb = openSQLite(storeFeatures, cfg.SQLite.Features)
+	lwoddb = openSQLite(storeLWOD, cfg.SQLite.LWOD)
+	ytvoddb = openSQLite(storeYTVods, cfg.SQLite.YTVods)
+	rumbledb = openSQLite(storeRumble, cfg.SQLite.Rumble)
+	omnimirrordb = openSQLite(storeOmnimirror, cfg.SQLite.Omnimirror)
+	embeddb = openSQLite(storeEmbeds, cfg.SQLite.Embeds)
+
+	if cfg.Postgres.Host != "" {
+		var err error
+		pgUrl := fmt.Sprintf("postgres://%s:%s@%s:%s/%s", cfg.Postgres.User, cfg.Postgres.Password, cfg.Postgres.Host, cfg.Postgres.Port, cfg.Postgres.DB)
+		pg, err = pgxpool.Connect(context.Background(), pgUrl)
+		if err != nil {
+			log.Fatalf("Error connecting to Postgres DB: %s", err)
+		}
+		checkPostgresTables()
+		available[storePostgres] = true
+	} else {
+		log.Infof("Postgres isn't configured, the phrases, nukes, mutelinks and logs endpoints are disabled")
 	}
 
-	pg, err = pgxpool.Connect(context.Background(), pgUrl)
-	if err != nil {
-		log.Fatalf("Error connecting to Postgres DB: %s", err)
+	if cfg.Redis.Host != "" {
+		rdb = redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       0,
+		})
+		rdb.AddHook(redisMetrics{})
+		available[storeRedis] = true
+	} else {
+		log.Infof("Redis isn't configured, the script, providers and webhooks endpoints and the event fan-out are disabled")
 	}
 
-	rdb = redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%s", redisHost, redisPort),
-		Password: redisPass,
-		DB:       0,
-	})
-
 	log.Infof("Connected to databases successfully")
 }
 
@@ -623,54 +464,89 @@ func compileRegexp() {
 }
 
 func main() {
-	loadDotEnv()
+	loadConfig()
 	loadDatabases()
 	compileRegexp()
+	refreshDatasets()
+
+	if cfg.PersistStamps {
+		if err := stamps.load(); err != nil {
+			log.Errorf("Couldn't load the persisted stamps: %s", err)
+		}
+	}
+
+	grpcServer = newGRPCServer()
+	grpcWeb = newGRPCWeb()
 
 	api := fiber.New(fiber.Config{
-		ProxyHeader:             "X-Forwarded-For",
-		EnableTrustedProxyCheck: true,
-		TrustedProxies:          []string{trustedProxyIP},
-		Immutable:               true,
+		Immutable: true,
 	})
 
-	api.Use(cors.New())
-	api.Use(limiter.New(limiter.Config{
-		Max: 60,
-	}))
-	api.Use(fiberLogger.New(fiberLogger.Config{
-		Format:     "[${time}] ${ip} - ${status} ${method} ${path}${query:} - ${latency}\n",
-		TimeFormat: "2006-01-02 15:04:05.000000 MST",
-	}))
-
-	api.Get(os.Getenv("API_PREFIX")+"/script/:dev?", getScript)
-	api.Get(os.Getenv("API_PREFIX")+"/features", getFeatures)
-	api.Get(os.Getenv("API_PREFIX")+"/ytvods", getYTvods)
-	api.Get(os.Getenv("API_PREFIX")+"/rumblevods", getRumbleVods)
-	api.Get(os.Getenv("API_PREFIX")+"/omnimirror", getOmnimirrorVods)
-	api.Get(os.Getenv("API_PREFIX")+"/embeds/:last?", getEmbeds)
-	api.Get(os.Getenv("API_PREFIX")+"/phrases", getPhrases)
-	api.Get(os.Getenv("API_PREFIX")+"/lwod", getLWOD)
-	api.Get(os.Getenv("API_PREFIX")+"/logs", getLogs)
-	api.Get(os.Getenv("API_PREFIX")+"/rawlogs", getRawLogs)
-	api.Get(os.Getenv("API_PREFIX")+"/nukes", getNukes)
-	api.Get(os.Getenv("API_PREFIX")+"/mutelinks", getMutelinks)
-	api.Get(os.Getenv("API_PREFIX")+"/msgcount", getMsgCount)
-	api.Get(os.Getenv("API_PREFIX")+"/lastlwod", getLastLWODSheet)
-	api.Get(os.Getenv("API_PREFIX")+"/nmptimestamps", checkStamps)
-	api.Get(os.Getenv("API_PREFIX")+"/providers", getProviders)
-
-	if os.Getenv("PORT") == "" {
-		log.Fatalf("Please set the PORT environment variable and restart the server")
+	api.Use(httpMetrics)
+	// the probes go before the limiter and the access log, an orchestrator
+	// polling them shouldn't use up a rate limit or flood the logs
+	api.Get(cfg.APIPrefix+"/healthz", getHealthz)
+	api.Get(cfg.APIPrefix+"/readyz", getReadyz)
+	api.Use(withRuntime)
+	api.Use(runtimeCORS)
+	api.Use(runtimeLimiter)
+	api.Use(log.AccessLog)
+
+	api.Get(cfg.APIPrefix+"/script/:dev?", requires(storeRedis), getScript)
+	api.Get(cfg.APIPrefix+"/features", requires(storeFeatures), conditional(featuresSnapshot.modified), getFeatures)
+	api.Get(cfg.APIPrefix+"/ytvods", requires(storeYTVods), conditional(ytvodsSnapshot.modified), getYTvods)
+	api.Get(cfg.APIPrefix+"/rumblevods", requires(storeRumble), conditional(rumbleSnapshot.modified), getRumbleVods)
+	api.Get(cfg.APIPrefix+"/omnimirror", requires(storeOmnimirror), conditional(omnimirrorSnapshot.modified), getOmnimirrorVods)
+	api.Get(cfg.APIPrefix+"/embeds/:last?", requires(storeEmbeds), getEmbeds)
+	api.Get(cfg.APIPrefix+"/phrases", requires(storePhrases), conditional(stampModified(stamps.phrases)), stampCache(stamps.phrases, "count", "ts"), getPhrases)
+	api.Get(cfg.APIPrefix+"/phrases/export", adminOnly, requires(storePhrases), getPhrasesExport)
+	api.Post(cfg.APIPrefix+"/phrases/import", adminOnly, requires(storePhrases), postPhrasesImport)
+	api.Get(cfg.APIPrefix+"/lwod", requires(storeLWOD), conditional(lwodSnapshot.modified), getLWOD)
+	api.Get(cfg.APIPrefix+"/logs", requires(storeLogs), getLogs)
+	api.Get(cfg.APIPrefix+"/rawlogs", requires(storeLogs), getRawLogs)
+	api.Get(cfg.APIPrefix+"/logs/live", requires(storeLogs), toggled(func(c *config) bool { return c.Toggles.LiveLogs }), getLiveLogs)
+	api.Get(cfg.APIPrefix+"/nukes", requires(storeNukes), conditional(nukesModified), stampCache(stamps.getter(stampNukes), "ts"), getNukes)
+	api.Get(cfg.APIPrefix+"/mutelinks", requires(storeMutelinks), conditional(stampModified(stamps.getter(stampMutelinks))), stampCache(stamps.getter(stampMutelinks), "ts"), getMutelinks)
+	api.Get(cfg.APIPrefix+"/msgcount", requires(storeLogs), getMsgCount)
+	api.Get(cfg.APIPrefix+"/lastlwod", requires(storeLWOD), getLastLWODSheet)
+	api.Get(cfg.APIPrefix+"/nmptimestamps", conditional(stampModified(latestStamp)), checkStamps)
+	api.Get(cfg.APIPrefix+"/providers", requires(storeRedis), getProviders)
+	api.Get(cfg.APIPrefix+"/datasets", getDatasets)
+	api.Get(cfg.APIPrefix+"/events", toggled(func(c *config) bool { return c.Toggles.Events }), getEvents)
+	api.Get(cfg.APIPrefix+"/webhooks", adminOnly, requires(storeRedis), getWebhooks)
+	api.Post(cfg.APIPrefix+"/webhooks", adminOnly, requires(storeRedis), postWebhook)
+	api.Delete(cfg.APIPrefix+"/webhooks/:id", adminOnly, requires(storeRedis), deleteWebhook)
+	api.Get(cfg.APIPrefix+"/webhooks/deadletter", adminOnly, requires(storeRedis), getWebhooksDeadLetter)
+	api.Post(cfg.APIPrefix+"/config/reload", adminOnly, postConfigReload)
+	api.Get(cfg.APIPrefix+"/metrics", getMetrics)
+	api.Post(cfg.APIPrefix+"/grpc_timestamps.Status/:method", toggled(func(c *config) bool { return c.Toggles.GRPCWeb }), grpcWebHandler)
+	api.Get(cfg.APIPrefix+"/ws", toggled(func(c *config) bool { return c.Toggles.WebSockets }), func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+		return c.Next()
+	}, websocket.New(wsSubscriptions))
+	api.Use(notFound)
+
+	background(watchStamps)
+	background(expiries.run)
+	if storeAvailable(storeRedis) {
+		background(subscribeFanout)
+		background(webhookDispatcher)
+	}
+	if storeAvailable(storeLogs) {
+		background(listenLogs)
 	}
+	background(watchDatasets)
+	background(watchReloads)
+	go gRPCServer()
 
 	go func() {
-		for {
-			doubleCheckStamps()
-			time.Sleep(time.Second * 15)
+		if err := api.Listen(":" + cfg.Port); err != nil {
+			log.Fatalf("Couldn't start the HTTP server: %s", err)
 		}
 	}()
-	go gRPCServer()
 
-	api.Listen(":" + os.Getenv("PORT"))
+	waitForSignal()
+	shutdown(api)
 }
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsNamespace = "vyneer_api"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route and status code.",
+	}, []string{"method", "route", "status"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, streaming routes only count the time until the stream starts.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	rateLimited = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "rate_limited_total",
+		Help:      "HTTP requests rejected by the rate limiter.",
+	})
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Query latency by store and query.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"store", "query"})
+	queryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "db_query_errors_total",
+		Help:      "Failed queries by store and query.",
+	}, []string{"store", "query"})
+	grpcRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "grpc_requests_total",
+		Help:      "Unary gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+	eventsIngested = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "events_ingested_total",
+		Help:      "Events received over gRPC by type and result.",
+	}, []string{"type", "result"})
+)
+
+func init() {
+	for _, name := range []string{stampPhrases, stampPhraseRemoval, stampNukes, stampMutelinks, stampEmbeds} {
+		name := name
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   metricsNamespace,
+			Name:        "stamp_milliseconds",
+			Help:        "Current value of each stamp, in unix milliseconds.",
+			ConstLabels: prometheus.Labels{"stamp": name},
+		}, func() float64 {
+			return float64(stamps.get(name))
+		})
+	}
+}
+
+// httpMetrics has to run before everything else so rate limited and
+// disabled routes get counted too, the route is the registered pattern
+// rather than the path to keep the label cardinality bounded
+func httpMetrics(c *fiber.Ctx) error {
+	start := time.Now()
+	err := c.Next()
+
+	code := c.Response().StatusCode()
+	if err != nil {
+		code = fiber.StatusInternalServerError
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			code = fiberErr.Code
+		}
+	}
+
+	route := c.Route().Path
+	if c.Locals(unmatchedKey) != nil {
+		route = "unmatched"
+	}
+
+	httpRequests.WithLabelValues(c.Method(), route, strconv.Itoa(code)).Inc()
+	httpDuration.WithLabelValues(c.Method(), route).Observe(time.Since(start).Seconds())
+	return err
+}
+
+const unmatchedKey = "unmatched"
+
+// notFound goes last, fiber leaves the route of an unmatched request
+// pointing at whichever middleware ran last so it has to be flagged
+func notFound(c *fiber.Ctx) error {
+	c.Locals(unmatchedKey, true)
+	return fiber.NewError(fiber.StatusNotFound, "Cannot "+c.Method()+" "+c.OriginalURL())
+}
+
+var getMetrics = adaptor.HTTPHandler(promhttp.Handler())
+
+// observe times fn and counts its errors under store and query
+func observe[T any](store string, query string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	v, err := fn()
+	queryDuration.WithLabelValues(store, query).Observe(time.Since(start).Seconds())
+	if err != nil {
+		queryErrors.WithLabelValues(store, query).Inc()
+	}
+	return v, err
+}
+
+func observeQuery(store string, query string, fn func() error) error {
+	_, err := observe(store, query, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// redisMetrics times every redis command, a missing key isn't an error
+type redisMetrics struct{}
+
+type redisStartKey struct{}
+
+func (redisMetrics) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (redisMetrics) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	observeRedis(ctx, cmd.Name(), cmd.Err())
+	return nil
+}
+
+func (redisMetrics) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (redisMetrics) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && !errors.Is(cmdErr, redis.Nil) {
+			err = cmdErr
+			break
+		}
+	}
+	observeRedis(ctx, "pipeline", err)
+	return nil
+}
+
+func observeRedis(ctx context.Context, name string, err error) {
+	start, ok := ctx.Value(redisStartKey{}).(time.Time)
+	if !ok {
+		return
+	}
+	queryDuration.WithLabelValues(storeRedis, name).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, redis.Nil) {
+		queryErrors.WithLabelValues(storeRedis, name).Inc()
+	}
+}
+
+func metricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	grpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	return resp, err
+}
@@ -0,0 +1,35 @@
+-- Sends every new logs row to the vyneer_api_logs channel for /logs/live.
+-- Apply it once to the database the scraper writes to, the API only LISTENs:
+--   psql "$DATABASE_URL" -f migrations/0001_logs_notify.sql
+--
+-- pg_notify raises an error for payloads of 8000 bytes or more, which would
+-- abort the scraper's INSERT, so a row that doesn't fit is sent as just its
+-- time and username and the API reads the rest from the table.
+
+CREATE OR REPLACE FUNCTION vyneer_api_notify_logs() RETURNS trigger AS $$
+DECLARE
+	payload text;
+BEGIN
+	payload := json_build_object(
+		'time', to_char(NEW.time, 'YYYY-MM-DD"T"HH24:MI:SS.MSZ'),
+		'username', NEW.username,
+		'features', NEW.features,
+		'message', NEW.message
+	)::text;
+
+	IF octet_length(payload) >= 7900 THEN
+		payload := json_build_object(
+			'time', to_char(NEW.time, 'YYYY-MM-DD"T"HH24:MI:SS.MSZ'),
+			'username', NEW.username,
+			'at', NEW.time,
+			'truncated', true
+		)::text;
+	END IF;
+
+	PERFORM pg_notify('vyneer_api_logs', payload);
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS vyneer_api_notify_logs ON logs;
+CREATE TRIGGER vyneer_api_notify_logs AFTER INSERT ON logs FOR EACH ROW EXECUTE FUNCTION vyneer_api_notify_logs();
@@ -67,6 +67,64 @@ func (AegisType) EnumDescriptor() ([]byte, []int) {
 	return file_grpc_timestamps_proto_rawDescGZIP(), []int{0}
 }
 
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	EventType_PHRASE                 EventType = 1
+	EventType_REMOVE_PHRASE          EventType = 2
+	EventType_NUKE                   EventType = 3
+	EventType_AEGIS                  EventType = 4
+	EventType_MUTELINKS              EventType = 5
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "PHRASE",
+		2: "REMOVE_PHRASE",
+		3: "NUKE",
+		4: "AEGIS",
+		5: "MUTELINKS",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"PHRASE":                 1,
+		"REMOVE_PHRASE":          2,
+		"NUKE":                   3,
+		"AEGIS":                  4,
+		"MUTELINKS":              5,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpc_timestamps_proto_enumTypes[1].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_grpc_timestamps_proto_enumTypes[1]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{1}
+}
+
 type Phrase struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -77,6 +135,8 @@ type Phrase struct {
 	Phrase   string                 `protobuf:"bytes,3,opt,name=phrase,proto3" json:"phrase,omitempty"`
 	Duration string                 `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
 	Type     string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Id       string                 `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	Source   string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
 }
 
 func (x *Phrase) Reset() {
@@ -146,6 +206,20 @@ func (x *Phrase) GetType() string {
 	return ""
 }
 
+func (x *Phrase) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Phrase) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type RemovePhrase struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -153,6 +227,8 @@ type RemovePhrase struct {
 
 	Time   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
 	Phrase string                 `protobuf:"bytes,2,opt,name=phrase,proto3" json:"phrase,omitempty"`
+	Id     string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Source string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
 }
 
 func (x *RemovePhrase) Reset() {
@@ -201,6 +277,20 @@ func (x *RemovePhrase) GetPhrase() string {
 	return ""
 }
 
+func (x *RemovePhrase) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemovePhrase) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type Nuke struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -210,6 +300,9 @@ type Nuke struct {
 	Type     string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	Duration string                 `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
 	Word     string                 `protobuf:"bytes,4,opt,name=word,proto3" json:"word,omitempty"`
+	Victims  string                 `protobuf:"bytes,5,opt,name=victims,proto3" json:"victims,omitempty"`
+	Id       string                 `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	Source   string                 `protobuf:"bytes,7,opt,name=source,proto3" json:"source,omitempty"`
 }
 
 func (x *Nuke) Reset() {
@@ -272,14 +365,37 @@ func (x *Nuke) GetWord() string {
 	return ""
 }
 
+func (x *Nuke) GetVictims() string {
+	if x != nil {
+		return x.Victims
+	}
+	return ""
+}
+
+func (x *Nuke) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Nuke) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type Aegis struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
-	Type AegisType              `protobuf:"varint,2,opt,name=type,proto3,enum=grpc_timestamps.AegisType" json:"type,omitempty"`
-	Word string                 `protobuf:"bytes,3,opt,name=word,proto3" json:"word,omitempty"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Type   AegisType              `protobuf:"varint,2,opt,name=type,proto3,enum=grpc_timestamps.AegisType" json:"type,omitempty"`
+	Word   string                 `protobuf:"bytes,3,opt,name=word,proto3" json:"word,omitempty"`
+	Id     string                 `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	Source string                 `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
 }
 
 func (x *Aegis) Reset() {
@@ -335,6 +451,20 @@ func (x *Aegis) GetWord() string {
 	return ""
 }
 
+func (x *Aegis) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Aegis) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type Mutelinks struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -344,6 +474,8 @@ type Mutelinks struct {
 	Status   string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	Duration string                 `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
 	User     string                 `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	Id       string                 `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	Source   string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
 }
 
 func (x *Mutelinks) Reset() {
@@ -406,6 +538,20 @@ func (x *Mutelinks) GetUser() string {
 	return ""
 }
 
+func (x *Mutelinks) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Mutelinks) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -444,183 +590,1014 @@ func (*Empty) Descriptor() ([]byte, []int) {
 	return file_grpc_timestamps_proto_rawDescGZIP(), []int{5}
 }
 
-var File_grpc_timestamps_proto protoreflect.FileDescriptor
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_grpc_timestamps_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x01, 0x0a, 0x06, 0x50, 0x68,
-	0x72, 0x61, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
-	0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x56, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x68, 0x72, 0x61,
-	0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65,
-	0x22, 0x7a, 0x0a, 0x04, 0x4e, 0x75, 0x6b, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x7b, 0x0a, 0x05,
-	0x41, 0x65, 0x67, 0x69, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x41, 0x65, 0x67, 0x69, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x09, 0x4d, 0x75,
-	0x74, 0x65, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x75,
-	0x73, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22,
-	0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x2a, 0x23, 0x0a, 0x09, 0x41, 0x65, 0x67, 0x69,
-	0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10,
-	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x10, 0x01, 0x32, 0xe8, 0x02,
-	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4e, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x65,
-	0x69, 0x76, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12,
-	0x1d, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x1a, 0x16,
-	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x65,
-	0x69, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x50, 0x68, 0x72, 0x61,
-	0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0b,
-	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4e, 0x75, 0x6b, 0x65, 0x12, 0x15, 0x2e, 0x67, 0x72,
-	0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4e, 0x75,
-	0x6b, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0c,
-	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x65, 0x67, 0x69, 0x73, 0x12, 0x16, 0x2e, 0x67,
-	0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x41,
-	0x65, 0x67, 0x69, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48,
-	0x0a, 0x10, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4d, 0x75, 0x74, 0x65, 0x6c, 0x69, 0x6e,
-	0x6b, 0x73, 0x12, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x1a, 0x16,
-	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Types []EventType `protobuf:"varint,1,rep,packed,name=types,proto3,enum=grpc_timestamps.EventType" json:"types,omitempty"`
 }
 
-var (
-	file_grpc_timestamps_proto_rawDescOnce sync.Once
-	file_grpc_timestamps_proto_rawDescData = file_grpc_timestamps_proto_rawDesc
-)
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_grpc_timestamps_proto_rawDescGZIP() []byte {
-	file_grpc_timestamps_proto_rawDescOnce.Do(func() {
-		file_grpc_timestamps_proto_rawDescData = protoimpl.X.CompressGZIP(file_grpc_timestamps_proto_rawDescData)
-	})
-	return file_grpc_timestamps_proto_rawDescData
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_grpc_timestamps_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_grpc_timestamps_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_grpc_timestamps_proto_goTypes = []interface{}{
-	(AegisType)(0),                // 0: grpc_timestamps.AegisType
-	(*Phrase)(nil),                // 1: grpc_timestamps.Phrase
-	(*RemovePhrase)(nil),          // 2: grpc_timestamps.RemovePhrase
-	(*Nuke)(nil),                  // 3: grpc_timestamps.Nuke
-	(*Aegis)(nil),                 // 4: grpc_timestamps.Aegis
-	(*Mutelinks)(nil),             // 5: grpc_timestamps.Mutelinks
-	(*Empty)(nil),                 // 6: grpc_timestamps.Empty
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_grpc_timestamps_proto_depIdxs = []int32{
-	7,  // 0: grpc_timestamps.Phrase.time:type_name -> google.protobuf.Timestamp
-	7,  // 1: grpc_timestamps.RemovePhrase.time:type_name -> google.protobuf.Timestamp
-	7,  // 2: grpc_timestamps.Nuke.time:type_name -> google.protobuf.Timestamp
-	7,  // 3: grpc_timestamps.Aegis.time:type_name -> google.protobuf.Timestamp
-	0,  // 4: grpc_timestamps.Aegis.type:type_name -> grpc_timestamps.AegisType
-	7,  // 5: grpc_timestamps.Mutelinks.time:type_name -> google.protobuf.Timestamp
-	2,  // 6: grpc_timestamps.Status.ReceiveRemovePhrase:input_type -> grpc_timestamps.RemovePhrase
-	1,  // 7: grpc_timestamps.Status.ReceivePhrase:input_type -> grpc_timestamps.Phrase
-	3,  // 8: grpc_timestamps.Status.ReceiveNuke:input_type -> grpc_timestamps.Nuke
-	4,  // 9: grpc_timestamps.Status.ReceiveAegis:input_type -> grpc_timestamps.Aegis
-	5,  // 10: grpc_timestamps.Status.ReceiveMutelinks:input_type -> grpc_timestamps.Mutelinks
-	6,  // 11: grpc_timestamps.Status.ReceiveRemovePhrase:output_type -> grpc_timestamps.Empty
-	6,  // 12: grpc_timestamps.Status.ReceivePhrase:output_type -> grpc_timestamps.Empty
-	6,  // 13: grpc_timestamps.Status.ReceiveNuke:output_type -> grpc_timestamps.Empty
-	6,  // 14: grpc_timestamps.Status.ReceiveAegis:output_type -> grpc_timestamps.Empty
-	6,  // 15: grpc_timestamps.Status.ReceiveMutelinks:output_type -> grpc_timestamps.Empty
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{6}
 }
 
-func init() { file_grpc_timestamps_proto_init() }
-func file_grpc_timestamps_proto_init() {
-	if File_grpc_timestamps_proto != nil {
-		return
+func (x *SubscribeRequest) GetTypes() []EventType {
+	if x != nil {
+		return x.Types
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_grpc_timestamps_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Phrase); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_grpc_timestamps_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemovePhrase); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+type PhrasesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *PhrasesRequest) Reset() {
+	*x = PhrasesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhrasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhrasesRequest) ProtoMessage() {}
+
+func (x *PhrasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_grpc_timestamps_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Nuke); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhrasesRequest.ProtoReflect.Descriptor instead.
+func (*PhrasesRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PhrasesRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PhraseList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phrases []*Phrase `protobuf:"bytes,1,rep,name=phrases,proto3" json:"phrases,omitempty"`
+}
+
+func (x *PhraseList) Reset() {
+	*x = PhraseList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhraseList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhraseList) ProtoMessage() {}
+
+func (x *PhraseList) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_grpc_timestamps_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Aegis); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhraseList.ProtoReflect.Descriptor instead.
+func (*PhraseList) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PhraseList) GetPhrases() []*Phrase {
+	if x != nil {
+		return x.Phrases
+	}
+	return nil
+}
+
+type NukeList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nukes []*Nuke `protobuf:"bytes,1,rep,name=nukes,proto3" json:"nukes,omitempty"`
+}
+
+func (x *NukeList) Reset() {
+	*x = NukeList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NukeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NukeList) ProtoMessage() {}
+
+func (x *NukeList) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NukeList.ProtoReflect.Descriptor instead.
+func (*NukeList) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NukeList) GetNukes() []*Nuke {
+	if x != nil {
+		return x.Nukes
+	}
+	return nil
+}
+
+type MutelinksList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mutelinks []*Mutelinks `protobuf:"bytes,1,rep,name=mutelinks,proto3" json:"mutelinks,omitempty"`
+}
+
+func (x *MutelinksList) Reset() {
+	*x = MutelinksList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MutelinksList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutelinksList) ProtoMessage() {}
+
+func (x *MutelinksList) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutelinksList.ProtoReflect.Descriptor instead.
+func (*MutelinksList) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MutelinksList) GetMutelinks() []*Mutelinks {
+	if x != nil {
+		return x.Mutelinks
+	}
+	return nil
+}
+
+type RawLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *RawLogsRequest) Reset() {
+	*x = RawLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawLogsRequest) ProtoMessage() {}
+
+func (x *RawLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawLogsRequest.ProtoReflect.Descriptor instead.
+func (*RawLogsRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RawLogsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *RawLogsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type LogLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Features string                 `protobuf:"bytes,3,opt,name=features,proto3" json:"features,omitempty"`
+	Message  string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LogLine) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *LogLine) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LogLine) GetFeatures() string {
+	if x != nil {
+		return x.Features
+	}
+	return ""
+}
+
+func (x *LogLine) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type EmbedsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Minutes int32 `protobuf:"varint,1,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	Last    bool  `protobuf:"varint,2,opt,name=last,proto3" json:"last,omitempty"`
+}
+
+func (x *EmbedsRequest) Reset() {
+	*x = EmbedsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmbedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedsRequest) ProtoMessage() {}
+
+func (x *EmbedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedsRequest.ProtoReflect.Descriptor instead.
+func (*EmbedsRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EmbedsRequest) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *EmbedsRequest) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
+type Embed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Link     string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	Platform string                 `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	Channel  string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Title    string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Count    int32                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	Time     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Embed) Reset() {
+	*x = Embed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Embed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embed) ProtoMessage() {}
+
+func (x *Embed) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embed.ProtoReflect.Descriptor instead.
+func (*Embed) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Embed) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Embed) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Embed) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Embed) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Embed) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Embed) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type EmbedList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Embeds []*Embed `protobuf:"bytes,1,rep,name=embeds,proto3" json:"embeds,omitempty"`
+}
+
+func (x *EmbedList) Reset() {
+	*x = EmbedList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmbedList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbedList) ProtoMessage() {}
+
+func (x *EmbedList) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbedList.ProtoReflect.Descriptor instead.
+func (*EmbedList) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EmbedList) GetEmbeds() []*Embed {
+	if x != nil {
+		return x.Embeds
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*Event_Phrase
+	//	*Event_RemovePhrase
+	//	*Event_Nuke
+	//	*Event_Aegis
+	//	*Event_Mutelinks
+	Event isEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_timestamps_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_timestamps_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_grpc_timestamps_proto_rawDescGZIP(), []int{16}
+}
+
+func (m *Event) GetEvent() isEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *Event) GetPhrase() *Phrase {
+	if x, ok := x.GetEvent().(*Event_Phrase); ok {
+		return x.Phrase
+	}
+	return nil
+}
+
+func (x *Event) GetRemovePhrase() *RemovePhrase {
+	if x, ok := x.GetEvent().(*Event_RemovePhrase); ok {
+		return x.RemovePhrase
+	}
+	return nil
+}
+
+func (x *Event) GetNuke() *Nuke {
+	if x, ok := x.GetEvent().(*Event_Nuke); ok {
+		return x.Nuke
+	}
+	return nil
+}
+
+func (x *Event) GetAegis() *Aegis {
+	if x, ok := x.GetEvent().(*Event_Aegis); ok {
+		return x.Aegis
+	}
+	return nil
+}
+
+func (x *Event) GetMutelinks() *Mutelinks {
+	if x, ok := x.GetEvent().(*Event_Mutelinks); ok {
+		return x.Mutelinks
+	}
+	return nil
+}
+
+type isEvent_Event interface {
+	isEvent_Event()
+}
+
+type Event_Phrase struct {
+	Phrase *Phrase `protobuf:"bytes,1,opt,name=phrase,proto3,oneof"`
+}
+
+type Event_RemovePhrase struct {
+	RemovePhrase *RemovePhrase `protobuf:"bytes,2,opt,name=remove_phrase,json=removePhrase,proto3,oneof"`
+}
+
+type Event_Nuke struct {
+	Nuke *Nuke `protobuf:"bytes,3,opt,name=nuke,proto3,oneof"`
+}
+
+type Event_Aegis struct {
+	Aegis *Aegis `protobuf:"bytes,4,opt,name=aegis,proto3,oneof"`
+}
+
+type Event_Mutelinks struct {
+	Mutelinks *Mutelinks `protobuf:"bytes,5,opt,name=mutelinks,proto3,oneof"`
+}
+
+func (*Event_Phrase) isEvent_Event() {}
+
+func (*Event_RemovePhrase) isEvent_Event() {}
+
+func (*Event_Nuke) isEvent_Event() {}
+
+func (*Event_Aegis) isEvent_Event() {}
+
+func (*Event_Mutelinks) isEvent_Event() {}
+
+var File_grpc_timestamps_proto protoreflect.FileDescriptor
+
+var file_grpc_timestamps_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc4, 0x01, 0x0a, 0x06, 0x50, 0x68,
+	0x72, 0x61, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x22, 0x7e, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65,
+	0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x22, 0xbc, 0x01, 0x0a, 0x04, 0x4e, 0x75, 0x6b, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x69, 0x63, 0x74, 0x69, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x69, 0x63, 0x74, 0x69, 0x6d, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22,
+	0xa3, 0x01, 0x0a, 0x05, 0x41, 0x65, 0x67, 0x69, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x41, 0x65, 0x67, 0x69, 0x73, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xab, 0x01, 0x0a, 0x09, 0x4d, 0x75, 0x74, 0x65, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x44, 0x0a, 0x10,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x30, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x22, 0x26, 0x0a, 0x0e, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3f, 0x0a, 0x0a, 0x50, 0x68,
+	0x72, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x68, 0x72, 0x61,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x50, 0x68, 0x72, 0x61,
+	0x73, 0x65, 0x52, 0x07, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x73, 0x22, 0x37, 0x0a, 0x08, 0x4e,
+	0x75, 0x6b, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x6e, 0x75, 0x6b, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4e, 0x75, 0x6b, 0x65, 0x52, 0x05, 0x6e,
+	0x75, 0x6b, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x0d, 0x4d, 0x75, 0x74, 0x65, 0x6c, 0x69, 0x6e, 0x6b,
+	0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x6d, 0x75, 0x74, 0x65, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x6c,
+	0x69, 0x6e, 0x6b, 0x73, 0x52, 0x09, 0x6d, 0x75, 0x74, 0x65, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x22,
+	0x6c, 0x0a, 0x0e, 0x52, 0x61, 0x77, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x8b, 0x01,
+	0x0a, 0x07, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3d, 0x0a, 0x0d, 0x45,
+	0x6d, 0x62, 0x65, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d,
+	0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x22, 0xad, 0x01, 0x0a, 0x05, 0x45,
+	0x6d, 0x62, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x3b, 0x0a, 0x09, 0x45, 0x6d,
+	0x62, 0x65, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x65, 0x6d, 0x62, 0x65, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x52,
+	0x06, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x73, 0x22, 0xa2, 0x02, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x31, 0x0a, 0x06, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x73, 0x2e, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x70, 0x68,
+	0x72, 0x61, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x70,
+	0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x6e, 0x75,
+	0x6b, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4e, 0x75, 0x6b, 0x65, 0x48,
+	0x00, 0x52, 0x04, 0x6e, 0x75, 0x6b, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x61, 0x65, 0x67, 0x69, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x41, 0x65, 0x67, 0x69, 0x73, 0x48, 0x00,
+	0x52, 0x05, 0x61, 0x65, 0x67, 0x69, 0x73, 0x12, 0x3a, 0x0a, 0x09, 0x6d, 0x75, 0x74, 0x65, 0x6c,
+	0x69, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x70,
+	0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4d, 0x75, 0x74,
+	0x65, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x48, 0x00, 0x52, 0x09, 0x6d, 0x75, 0x74, 0x65, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x23, 0x0a, 0x09,
+	0x41, 0x65, 0x67, 0x69, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52,
+	0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x10,
+	0x01, 0x2a, 0x6a, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a,
+	0x0a, 0x16, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x48,
+	0x52, 0x41, 0x53, 0x45, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x45, 0x4d, 0x4f, 0x56, 0x45,
+	0x5f, 0x50, 0x48, 0x52, 0x41, 0x53, 0x45, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x55, 0x4b,
+	0x45, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x45, 0x47, 0x49, 0x53, 0x10, 0x04, 0x12, 0x0d,
+	0x0a, 0x09, 0x4d, 0x55, 0x54, 0x45, 0x4c, 0x49, 0x4e, 0x4b, 0x53, 0x10, 0x05, 0x32, 0xa5, 0x06,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4e, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12,
+	0x1d, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x1a, 0x16,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x67, 0x72, 0x70, 0x63,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x50, 0x68, 0x72, 0x61,
+	0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0b,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4e, 0x75, 0x6b, 0x65, 0x12, 0x15, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4e, 0x75,
+	0x6b, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0c,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x65, 0x67, 0x69, 0x73, 0x12, 0x16, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x41,
+	0x65, 0x67, 0x69, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48,
+	0x0a, 0x10, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4d, 0x75, 0x74, 0x65, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x12, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x1a, 0x16,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x21, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x68, 0x72, 0x61, 0x73,
+	0x65, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x73, 0x2e, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x50, 0x68, 0x72, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x22, 0x00, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4e, 0x75, 0x6b, 0x65, 0x73, 0x12, 0x16,
+	0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4e, 0x75, 0x6b, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4d, 0x75, 0x74, 0x65, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4d, 0x75,
+	0x74, 0x65, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x4b, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x52, 0x61, 0x77, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x72,
+	0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x52, 0x61,
+	0x77, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67,
+	0x72, 0x70, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x4c,
+	0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x49, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x73, 0x12, 0x1e, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x2e, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x4c,
+	0x69, 0x73, 0x74, 0x22, 0x00, 0x42, 0x08, 0x5a, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_grpc_timestamps_proto_rawDescOnce sync.Once
+	file_grpc_timestamps_proto_rawDescData = file_grpc_timestamps_proto_rawDesc
+)
+
+func file_grpc_timestamps_proto_rawDescGZIP() []byte {
+	file_grpc_timestamps_proto_rawDescOnce.Do(func() {
+		file_grpc_timestamps_proto_rawDescData = protoimpl.X.CompressGZIP(file_grpc_timestamps_proto_rawDescData)
+	})
+	return file_grpc_timestamps_proto_rawDescData
+}
+
+var file_grpc_timestamps_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_grpc_timestamps_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_grpc_timestamps_proto_goTypes = []interface{}{
+	(AegisType)(0),                // 0: grpc_timestamps.AegisType
+	(EventType)(0),                // 1: grpc_timestamps.EventType
+	(*Phrase)(nil),                // 2: grpc_timestamps.Phrase
+	(*RemovePhrase)(nil),          // 3: grpc_timestamps.RemovePhrase
+	(*Nuke)(nil),                  // 4: grpc_timestamps.Nuke
+	(*Aegis)(nil),                 // 5: grpc_timestamps.Aegis
+	(*Mutelinks)(nil),             // 6: grpc_timestamps.Mutelinks
+	(*Empty)(nil),                 // 7: grpc_timestamps.Empty
+	(*SubscribeRequest)(nil),      // 8: grpc_timestamps.SubscribeRequest
+	(*PhrasesRequest)(nil),        // 9: grpc_timestamps.PhrasesRequest
+	(*PhraseList)(nil),            // 10: grpc_timestamps.PhraseList
+	(*NukeList)(nil),              // 11: grpc_timestamps.NukeList
+	(*MutelinksList)(nil),         // 12: grpc_timestamps.MutelinksList
+	(*RawLogsRequest)(nil),        // 13: grpc_timestamps.RawLogsRequest
+	(*LogLine)(nil),               // 14: grpc_timestamps.LogLine
+	(*EmbedsRequest)(nil),         // 15: grpc_timestamps.EmbedsRequest
+	(*Embed)(nil),                 // 16: grpc_timestamps.Embed
+	(*EmbedList)(nil),             // 17: grpc_timestamps.EmbedList
+	(*Event)(nil),                 // 18: grpc_timestamps.Event
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
+}
+var file_grpc_timestamps_proto_depIdxs = []int32{
+	19, // 0: grpc_timestamps.Phrase.time:type_name -> google.protobuf.Timestamp
+	19, // 1: grpc_timestamps.RemovePhrase.time:type_name -> google.protobuf.Timestamp
+	19, // 2: grpc_timestamps.Nuke.time:type_name -> google.protobuf.Timestamp
+	19, // 3: grpc_timestamps.Aegis.time:type_name -> google.protobuf.Timestamp
+	0,  // 4: grpc_timestamps.Aegis.type:type_name -> grpc_timestamps.AegisType
+	19, // 5: grpc_timestamps.Mutelinks.time:type_name -> google.protobuf.Timestamp
+	1,  // 6: grpc_timestamps.SubscribeRequest.types:type_name -> grpc_timestamps.EventType
+	2,  // 7: grpc_timestamps.PhraseList.phrases:type_name -> grpc_timestamps.Phrase
+	4,  // 8: grpc_timestamps.NukeList.nukes:type_name -> grpc_timestamps.Nuke
+	6,  // 9: grpc_timestamps.MutelinksList.mutelinks:type_name -> grpc_timestamps.Mutelinks
+	19, // 10: grpc_timestamps.RawLogsRequest.from:type_name -> google.protobuf.Timestamp
+	19, // 11: grpc_timestamps.RawLogsRequest.to:type_name -> google.protobuf.Timestamp
+	19, // 12: grpc_timestamps.LogLine.time:type_name -> google.protobuf.Timestamp
+	19, // 13: grpc_timestamps.Embed.time:type_name -> google.protobuf.Timestamp
+	16, // 14: grpc_timestamps.EmbedList.embeds:type_name -> grpc_timestamps.Embed
+	2,  // 15: grpc_timestamps.Event.phrase:type_name -> grpc_timestamps.Phrase
+	3,  // 16: grpc_timestamps.Event.remove_phrase:type_name -> grpc_timestamps.RemovePhrase
+	4,  // 17: grpc_timestamps.Event.nuke:type_name -> grpc_timestamps.Nuke
+	5,  // 18: grpc_timestamps.Event.aegis:type_name -> grpc_timestamps.Aegis
+	6,  // 19: grpc_timestamps.Event.mutelinks:type_name -> grpc_timestamps.Mutelinks
+	3,  // 20: grpc_timestamps.Status.ReceiveRemovePhrase:input_type -> grpc_timestamps.RemovePhrase
+	2,  // 21: grpc_timestamps.Status.ReceivePhrase:input_type -> grpc_timestamps.Phrase
+	4,  // 22: grpc_timestamps.Status.ReceiveNuke:input_type -> grpc_timestamps.Nuke
+	5,  // 23: grpc_timestamps.Status.ReceiveAegis:input_type -> grpc_timestamps.Aegis
+	6,  // 24: grpc_timestamps.Status.ReceiveMutelinks:input_type -> grpc_timestamps.Mutelinks
+	8,  // 25: grpc_timestamps.Status.Subscribe:input_type -> grpc_timestamps.SubscribeRequest
+	9,  // 26: grpc_timestamps.Status.GetPhrases:input_type -> grpc_timestamps.PhrasesRequest
+	7,  // 27: grpc_timestamps.Status.GetNukes:input_type -> grpc_timestamps.Empty
+	7,  // 28: grpc_timestamps.Status.GetMutelinks:input_type -> grpc_timestamps.Empty
+	13, // 29: grpc_timestamps.Status.GetRawLogs:input_type -> grpc_timestamps.RawLogsRequest
+	15, // 30: grpc_timestamps.Status.GetEmbeds:input_type -> grpc_timestamps.EmbedsRequest
+	7,  // 31: grpc_timestamps.Status.ReceiveRemovePhrase:output_type -> grpc_timestamps.Empty
+	7,  // 32: grpc_timestamps.Status.ReceivePhrase:output_type -> grpc_timestamps.Empty
+	7,  // 33: grpc_timestamps.Status.ReceiveNuke:output_type -> grpc_timestamps.Empty
+	7,  // 34: grpc_timestamps.Status.ReceiveAegis:output_type -> grpc_timestamps.Empty
+	7,  // 35: grpc_timestamps.Status.ReceiveMutelinks:output_type -> grpc_timestamps.Empty
+	18, // 36: grpc_timestamps.Status.Subscribe:output_type -> grpc_timestamps.Event
+	10, // 37: grpc_timestamps.Status.GetPhrases:output_type -> grpc_timestamps.PhraseList
+	11, // 38: grpc_timestamps.Status.GetNukes:output_type -> grpc_timestamps.NukeList
+	12, // 39: grpc_timestamps.Status.GetMutelinks:output_type -> grpc_timestamps.MutelinksList
+	14, // 40: grpc_timestamps.Status.GetRawLogs:output_type -> grpc_timestamps.LogLine
+	17, // 41: grpc_timestamps.Status.GetEmbeds:output_type -> grpc_timestamps.EmbedList
+	31, // [31:42] is the sub-list for method output_type
+	20, // [20:31] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_grpc_timestamps_proto_init() }
+func file_grpc_timestamps_proto_init() {
+	if File_grpc_timestamps_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_grpc_timestamps_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Phrase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemovePhrase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nuke); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aegis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
 		file_grpc_timestamps_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Mutelinks); i {
@@ -646,14 +1623,153 @@ func file_grpc_timestamps_proto_init() {
 				return nil
 			}
 		}
+		file_grpc_timestamps_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhrasesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhraseList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NukeList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MutelinksList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmbedsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Embed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmbedList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_timestamps_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_grpc_timestamps_proto_msgTypes[16].OneofWrappers = []interface{}{
+		(*Event_Phrase)(nil),
+		(*Event_RemovePhrase)(nil),
+		(*Event_Nuke)(nil),
+		(*Event_Aegis)(nil),
+		(*Event_Mutelinks)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_grpc_timestamps_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
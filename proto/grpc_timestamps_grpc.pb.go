@@ -27,6 +27,12 @@ type StatusClient interface {
 	ReceiveNuke(ctx context.Context, in *Nuke, opts ...grpc.CallOption) (*Empty, error)
 	ReceiveAegis(ctx context.Context, in *Aegis, opts ...grpc.CallOption) (*Empty, error)
 	ReceiveMutelinks(ctx context.Context, in *Mutelinks, opts ...grpc.CallOption) (*Empty, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Status_SubscribeClient, error)
+	GetPhrases(ctx context.Context, in *PhrasesRequest, opts ...grpc.CallOption) (*PhraseList, error)
+	GetNukes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NukeList, error)
+	GetMutelinks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MutelinksList, error)
+	GetRawLogs(ctx context.Context, in *RawLogsRequest, opts ...grpc.CallOption) (Status_GetRawLogsClient, error)
+	GetEmbeds(ctx context.Context, in *EmbedsRequest, opts ...grpc.CallOption) (*EmbedList, error)
 }
 
 type statusClient struct {
@@ -82,6 +88,106 @@ func (c *statusClient) ReceiveMutelinks(ctx context.Context, in *Mutelinks, opts
 	return out, nil
 }
 
+func (c *statusClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Status_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Status_ServiceDesc.Streams[0], "/grpc_timestamps.Status/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statusSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Status_SubscribeClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type statusSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *statusSubscribeClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *statusClient) GetPhrases(ctx context.Context, in *PhrasesRequest, opts ...grpc.CallOption) (*PhraseList, error) {
+	out := new(PhraseList)
+	err := c.cc.Invoke(ctx, "/grpc_timestamps.Status/GetPhrases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) GetNukes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NukeList, error) {
+	out := new(NukeList)
+	err := c.cc.Invoke(ctx, "/grpc_timestamps.Status/GetNukes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) GetMutelinks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MutelinksList, error) {
+	out := new(MutelinksList)
+	err := c.cc.Invoke(ctx, "/grpc_timestamps.Status/GetMutelinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *statusClient) GetRawLogs(ctx context.Context, in *RawLogsRequest, opts ...grpc.CallOption) (Status_GetRawLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Status_ServiceDesc.Streams[1], "/grpc_timestamps.Status/GetRawLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statusGetRawLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Status_GetRawLogsClient interface {
+	Recv() (*LogLine, error)
+	grpc.ClientStream
+}
+
+type statusGetRawLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *statusGetRawLogsClient) Recv() (*LogLine, error) {
+	m := new(LogLine)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *statusClient) GetEmbeds(ctx context.Context, in *EmbedsRequest, opts ...grpc.CallOption) (*EmbedList, error) {
+	out := new(EmbedList)
+	err := c.cc.Invoke(ctx, "/grpc_timestamps.Status/GetEmbeds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatusServer is the server API for Status service.
 // All implementations must embed UnimplementedStatusServer
 // for forward compatibility
@@ -91,6 +197,12 @@ type StatusServer interface {
 	ReceiveNuke(context.Context, *Nuke) (*Empty, error)
 	ReceiveAegis(context.Context, *Aegis) (*Empty, error)
 	ReceiveMutelinks(context.Context, *Mutelinks) (*Empty, error)
+	Subscribe(*SubscribeRequest, Status_SubscribeServer) error
+	GetPhrases(context.Context, *PhrasesRequest) (*PhraseList, error)
+	GetNukes(context.Context, *Empty) (*NukeList, error)
+	GetMutelinks(context.Context, *Empty) (*MutelinksList, error)
+	GetRawLogs(*RawLogsRequest, Status_GetRawLogsServer) error
+	GetEmbeds(context.Context, *EmbedsRequest) (*EmbedList, error)
 	mustEmbedUnimplementedStatusServer()
 }
 
@@ -113,6 +225,24 @@ func (UnimplementedStatusServer) ReceiveAegis(context.Context, *Aegis) (*Empty,
 func (UnimplementedStatusServer) ReceiveMutelinks(context.Context, *Mutelinks) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReceiveMutelinks not implemented")
 }
+func (UnimplementedStatusServer) Subscribe(*SubscribeRequest, Status_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedStatusServer) GetPhrases(context.Context, *PhrasesRequest) (*PhraseList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPhrases not implemented")
+}
+func (UnimplementedStatusServer) GetNukes(context.Context, *Empty) (*NukeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNukes not implemented")
+}
+func (UnimplementedStatusServer) GetMutelinks(context.Context, *Empty) (*MutelinksList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMutelinks not implemented")
+}
+func (UnimplementedStatusServer) GetRawLogs(*RawLogsRequest, Status_GetRawLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRawLogs not implemented")
+}
+func (UnimplementedStatusServer) GetEmbeds(context.Context, *EmbedsRequest) (*EmbedList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmbeds not implemented")
+}
 func (UnimplementedStatusServer) mustEmbedUnimplementedStatusServer() {}
 
 // UnsafeStatusServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +346,120 @@ func _Status_ReceiveMutelinks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Status_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatusServer).Subscribe(m, &statusSubscribeServer{stream})
+}
+
+type Status_SubscribeServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type statusSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *statusSubscribeServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Status_GetPhrases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PhrasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetPhrases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_timestamps.Status/GetPhrases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetPhrases(ctx, req.(*PhrasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_GetNukes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetNukes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_timestamps.Status/GetNukes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetNukes(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_GetMutelinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetMutelinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_timestamps.Status/GetMutelinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetMutelinks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Status_GetRawLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RawLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatusServer).GetRawLogs(m, &statusGetRawLogsServer{stream})
+}
+
+type Status_GetRawLogsServer interface {
+	Send(*LogLine) error
+	grpc.ServerStream
+}
+
+type statusGetRawLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *statusGetRawLogsServer) Send(m *LogLine) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Status_GetEmbeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetEmbeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_timestamps.Status/GetEmbeds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetEmbeds(ctx, req.(*EmbedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Status_ServiceDesc is the grpc.ServiceDesc for Status service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,7 +487,34 @@ var Status_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReceiveMutelinks",
 			Handler:    _Status_ReceiveMutelinks_Handler,
 		},
+		{
+			MethodName: "GetPhrases",
+			Handler:    _Status_GetPhrases_Handler,
+		},
+		{
+			MethodName: "GetNukes",
+			Handler:    _Status_GetNukes_Handler,
+		},
+		{
+			MethodName: "GetMutelinks",
+			Handler:    _Status_GetMutelinks_Handler,
+		},
+		{
+			MethodName: "GetEmbeds",
+			Handler:    _Status_GetEmbeds_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Status_Subscribe_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetRawLogs",
+			Handler:       _Status_GetRawLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "grpc_timestamps.proto",
 }
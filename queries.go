@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strconv"
+	_ "time/tzdata"
+
+	"github.com/jackc/pgtype"
+	"golang.org/x/sync/singleflight"
+)
+
+// queries lets concurrent identical queries share a single database
+// round-trip, which matters right after a stamp bump when every client
+// refetches within the same second
+var queries singleflight.Group
+
+// coalesce runs fn once for all the callers asking for the same key at the
+// same time, they all get the same result so it must not be modified
+func coalesce[T any](key string, fn func() (T, error)) (T, error) {
+	v, err, _ := queries.Do(key, func() (interface{}, error) {
+		return fn()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+// atStamp puts the stamp in a coalesce key, the stamp is read before the
+// query starts so a caller that saw a newer stamp never joins a query
+// that started before the stamp moved and gets stale rows back
+func atStamp(key string, stamp int64) string {
+	return key + "@" + strconv.FormatInt(stamp, 10)
+}
+
+func nukes() ([]nuke, error) {
+	return coalesce(atStamp("nukes", stamps.get(stampNukes)), func() ([]nuke, error) {
+		data, err := observe(storePostgres, "nukes", queryNukes)
+		if err == nil {
+			scheduleNukeExpiries(data)
+		}
+		return data, err
+	})
+}
+
+func phrases(countString string) ([]phrase, error) {
+	return coalesce(atStamp("phrases?count="+countString, stamps.phrases()), func() ([]phrase, error) {
+		return observe(storePostgres, "phrases", func() ([]phrase, error) {
+			return queryPhrases(countString)
+		})
+	})
+}
+
+func mutelinks() ([]mutelinksStatus, error) {
+	return coalesce(atStamp("mutelinks", stamps.get(stampMutelinks)), func() ([]mutelinksStatus, error) {
+		return observe(storePostgres, "mutelinks", queryMutelinks)
+	})
+}
+
+func logs(from string, to string) (map[int64][]pgtype.JSON, error) {
+	return observe(storePostgres, "logs", func() (map[int64][]pgtype.JSON, error) {
+		return queryLogs(from, to)
+	})
+}
+
+// rawLogs isn't coalesced since every caller streams the rows on its own
+func rawLogs(from string, to string, fn func(logLineString) error) error {
+	return observeQuery(storePostgres, "rawlogs", func() error {
+		return queryRawLogs(from, to, fn)
+	})
+}
+
+func embeds(minutes int) ([]embed, error) {
+	return coalesce("embeds?minutes="+strconv.Itoa(minutes), func() ([]embed, error) {
+		return observe(storeEmbeds, "embeds", func() ([]embed, error) {
+			return queryEmbeds(minutes)
+		})
+	})
+}
+
+func lastEmbeds() ([]lastembed, error) {
+	return coalesce("lastembeds", func() ([]lastembed, error) {
+		return observe(storeEmbeds, "lastembeds", queryLastEmbeds)
+	})
+}
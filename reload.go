@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+// reloadable lists the top-level config keys that take effect without a
+// restart, everything else is only read once at startup through cfg
+var reloadable = map[string]bool{
+	"trustedProxies": true,
+	"logLevel":       true,
+	"logFormat":      true,
+	"rateLimit":      true,
+	"cors":           true,
+	"toggles":        true,
+}
+
+const runtimeKey = "runtime"
+
+// runtimeState is everything built from the reloadable settings, a request
+// grabs it once so it never sees half of an old config and half of a new one
+type runtimeState struct {
+	cfg     *config
+	proxies []*net.IPNet
+	cors    fiber.Handler
+	limiter fiber.Handler
+}
+
+var live atomic.Pointer[runtimeState]
+
+// reloadMu makes concurrent reloads (a SIGHUP racing
+// the admin endpoint) apply one after the other
+var reloadMu sync.Mutex
+
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := []*net.IPNet{}
+	for _, proxy := range proxies {
+		if strings.Contains(proxy, "/") {
+			_, ipNet, err := net.ParseCIDR(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("%q isn't a valid IP range", proxy)
+			}
+			nets = append(nets, ipNet)
+			continue
+		}
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			return nil, fmt.Errorf("%q isn't a valid IP", proxy)
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return nets, nil
+}
+
+// newRuntimeState builds the middleware for c, the limiter is only rebuilt
+// when its settings change since a new one starts counting from zero
+func newRuntimeState(c *config, previous *runtimeState) *runtimeState {
+	proxies, _ := parseTrustedProxies(c.TrustedProxies)
+	s := &runtimeState{
+		cfg:     c,
+		proxies: proxies,
+	}
+
+	if previous != nil && previous.cfg.CORS == c.CORS {
+		s.cors = previous.cors
+	} else {
+		s.cors = cors.New(cors.Config{
+			AllowOrigins:     c.CORS.AllowOrigins,
+			AllowMethods:     c.CORS.AllowMethods,
+			AllowHeaders:     c.CORS.AllowHeaders,
+			AllowCredentials: c.CORS.AllowCredentials,
+			ExposeHeaders:    grpcWebExposedHeaders,
+			MaxAge:           c.CORS.MaxAge,
+		})
+	}
+
+	if previous != nil && previous.cfg.RateLimit == c.RateLimit {
+		s.limiter = previous.limiter
+	} else {
+		s.limiter = limiter.New(limiter.Config{
+			Max:        c.RateLimit.Max,
+			Expiration: c.RateLimit.Expiration,
+			LimitReached: func(c *fiber.Ctx) error {
+				rateLimited.Inc()
+				return c.SendStatus(fiber.StatusTooManyRequests)
+			},
+		})
+	}
+
+	return s
+}
+
+// applyConfig swaps in the reloadable settings of an already validated config
+func applyConfig(c *config) {
+	level, _ := log.ParseLevel(c.LogLevel)
+	logHandler.SetLevel(level)
+	format, _ := log.ParseFormat(c.LogFormat)
+	logHandler.SetFormat(format)
+	live.Store(newRuntimeState(c, live.Load()))
+}
+
+// changedKeys returns the top-level config keys that differ between a and b
+func changedKeys(a *config, b *config) []string {
+	changed := []string{}
+	va := reflect.ValueOf(*a)
+	vb := reflect.ValueOf(*b)
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			changed = append(changed, va.Type().Field(i).Tag.Get("yaml"))
+		}
+	}
+	return changed
+}
+
+// reloadConfig re-reads every config source and applies the reloadable
+// settings, an invalid config is rejected as a whole and nothing changes
+func reloadConfig() (applied []string, ignored []string, problems []string) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	c, problems := loadConfigFrom(configPath, configFlags)
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			log.Errorf("Configuration problem: %s", problem)
+		}
+		log.Errorf("Not reloading the configuration, found %d problem(s)", len(problems))
+		return nil, nil, problems
+	}
+
+	applied = []string{}
+	ignored = []string{}
+	for _, key := range changedKeys(live.Load().cfg, &c) {
+		if reloadable[key] {
+			applied = append(applied, key)
+		}
+	}
+	// the rest is compared to what the server started with,
+	// so it keeps getting reported until there's a restart
+	for _, key := range changedKeys(&cfg, &c) {
+		if !reloadable[key] {
+			ignored = append(ignored, key)
+		}
+	}
+
+	applyConfig(&c)
+
+	if len(applied) > 0 {
+		log.Infof("Reloaded the configuration, applied: %s", strings.Join(applied, ", "))
+	} else {
+		log.Infof("Reloaded the configuration, nothing that can be reloaded changed")
+	}
+	if len(ignored) > 0 {
+		log.Warnf("These settings changed but only apply after a restart: %s", strings.Join(ignored, ", "))
+	}
+	return applied, ignored, nil
+}
+
+func watchReloads(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			log.Infof("Got a SIGHUP, reloading the configuration")
+			reloadConfig()
+		}
+	}
+}
+
+func postConfigReload(c *fiber.Ctx) error {
+	applied, ignored, problems := reloadConfig()
+	if len(problems) > 0 {
+		return c.Status(400).JSON(fiber.Map{
+			"problems": problems,
+		})
+	}
+	return c.JSON(fiber.Map{
+		"applied":         applied,
+		"requiresRestart": ignored,
+	})
+}
+
+func currentRuntime(c *fiber.Ctx) *runtimeState {
+	if s, ok := c.Locals(runtimeKey).(*runtimeState); ok {
+		return s
+	}
+	return live.Load()
+}
+
+// withRuntime pins the runtime state for the request and resolves the
+// client IP, it replaces fiber's own trusted proxy handling since that
+// can't change once the app is created
+func withRuntime(c *fiber.Ctx) error {
+	s := live.Load()
+	c.Locals(runtimeKey, s)
+
+	remote := c.Context().RemoteIP()
+	for _, proxy := range s.proxies {
+		if !proxy.Contains(remote) {
+			continue
+		}
+		for _, forwarded := range strings.Split(c.Get(fiber.HeaderXForwardedFor), ",") {
+			if ip := net.ParseIP(strings.TrimSpace(forwarded)); ip != nil {
+				c.Context().SetRemoteAddr(&net.TCPAddr{IP: ip})
+				break
+			}
+		}
+		break
+	}
+
+	return c.Next()
+}
+
+func runtimeCORS(c *fiber.Ctx) error {
+	return currentRuntime(c).cors(c)
+}
+
+func runtimeLimiter(c *fiber.Ctx) error {
+	return currentRuntime(c).limiter(c)
+}
+
+// toggled turns the route off with a 503 while its toggle is disabled
+func toggled(enabled func(c *config) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !enabled(currentRuntime(c).cfg) {
+			return c.Status(503).SendString("This feature is disabled")
+		}
+		return c.Next()
+	}
+}
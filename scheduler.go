@@ -0,0 +1,263 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	log "github.com/vyneer/vyneer-api/logger"
+	pb "github.com/vyneer/vyneer-api/proto"
+)
+
+// nukesWindow is how far back nukes() looks, a nuke drops out of
+// the /nukes output after this even if it lasts longer
+const nukesWindow = time.Minute * 5
+
+const defaultModDuration = time.Minute * 10
+
+// clock lets the scheduler run against a fake time source
+type clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) timer
+}
+
+type timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type realClock struct{}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) timer {
+	return realTimer{t: time.NewTimer(d)}
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t realTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+type expiry struct {
+	At    time.Time
+	Topic string
+	What  string
+}
+
+type expiryQueue []expiry
+
+func (q expiryQueue) Len() int           { return len(q) }
+func (q expiryQueue) Less(i, j int) bool { return q[i].At.Before(q[j].At) }
+func (q expiryQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *expiryQueue) Push(x interface{}) {
+	*q = append(*q, x.(expiry))
+}
+
+func (q *expiryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
+
+type scheduler struct {
+	clock clock
+	fire  func(e expiry, now time.Time)
+	mu    sync.Mutex
+	queue expiryQueue
+	wake  chan struct{}
+}
+
+var expiries = newScheduler(realClock{}, fireExpiry)
+
+func newScheduler(c clock, fire func(e expiry, now time.Time)) *scheduler {
+	return &scheduler{
+		clock: c,
+		fire:  fire,
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+func (s *scheduler) schedule(e expiry) {
+	s.mu.Lock()
+	heap.Push(&s.queue, e)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *scheduler) run(ctx context.Context) {
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+			continue
+		}
+
+		next := s.queue[0]
+		now := s.clock.Now()
+		wait := next.At.Sub(now)
+		if wait <= 0 {
+			heap.Pop(&s.queue)
+			s.mu.Unlock()
+			s.fire(next, now)
+			continue
+		}
+		s.mu.Unlock()
+
+		t := s.clock.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-s.wake:
+			// something got scheduled, it might be due sooner
+			t.Stop()
+		case <-t.C():
+		}
+	}
+}
+
+func fireExpiry(e expiry, now time.Time) {
+	switch e.Topic {
+	case topicNukes:
+		stamps.update(stampNukes, now.UnixMilli(), fmt.Sprintf("The %s nuke expired", e.What))
+	case topicMutelinks:
+		stamps.update(stampMutelinks, now.UnixMilli(), fmt.Sprintf("The mutelinks %s expired", e.What))
+	case topicPhrases:
+		stamps.update(stampPhraseRemoval, now.UnixMilli(), fmt.Sprintf("The %s phrase expired", e.What))
+	}
+}
+
+// parseModDuration parses the durations the chat bots use,
+// like 10m, 1H or 2d, an empty duration means the bot's default
+func parseModDuration(duration string) (time.Duration, error) {
+	if duration == "" {
+		return defaultModDuration, nil
+	}
+	if len(duration) < 2 {
+		return 0, fmt.Errorf("invalid duration: %s", duration)
+	}
+
+	value, err := strconv.Atoi(duration[:len(duration)-1])
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid duration: %s", duration)
+	}
+
+	switch strings.ToLower(duration[len(duration)-1:]) {
+	case "s":
+		return time.Duration(value) * time.Second, nil
+	case "m":
+		return time.Duration(value) * time.Minute, nil
+	case "h":
+		return time.Duration(value) * time.Hour, nil
+	case "d":
+		return time.Duration(value) * time.Hour * 24, nil
+	case "w":
+		return time.Duration(value) * time.Hour * 24 * 7, nil
+	}
+	return 0, fmt.Errorf("invalid duration unit: %s", duration)
+}
+
+// scheduledNukes remembers which nuke rows already have an expiry, the
+// entries are kept well past the expiry so a DB clock running a bit behind
+// ours can't get a row that just aged out scheduled over and over
+var scheduledNukes = struct {
+	sync.Mutex
+	at map[string]time.Time
+}{at: make(map[string]time.Time)}
+
+// scheduleNukeExpiries moves the nukes stamp once each of the rows drops
+// out of the nukesWindow, the events only cover nukes received over gRPC,
+// not the ones reconcileStamp finds, ones with a duration we can't parse
+// or ones that were pending when the process restarted
+func scheduleNukeExpiries(data []nuke) {
+	now := expiries.clock.Now()
+
+	scheduledNukes.Lock()
+	defer scheduledNukes.Unlock()
+	for key, at := range scheduledNukes.at {
+		if now.Sub(at) > nukesWindow {
+			delete(scheduledNukes.at, key)
+		}
+	}
+	for _, n := range data {
+		key := n.Time.String() + "/" + n.Word
+		if _, ok := scheduledNukes.at[key]; ok {
+			continue
+		}
+		at := n.Time.Add(nukesWindow)
+		scheduledNukes.at[key] = at
+		expiries.schedule(expiry{At: at, Topic: topicNukes, What: n.Word})
+	}
+}
+
+// scheduleExpiry figures out when the data behind an event stops
+// being current, events that never expire are ignored
+func scheduleExpiry(e event) {
+	var exp expiry
+	switch e.Type {
+	case eventNuke:
+		n := e.Data.(*pb.Nuke)
+		d, err := parseModDuration(n.Duration)
+		if err != nil {
+			log.Warnf("Couldn't schedule the %s nuke expiry: %s", n.Word, err)
+			return
+		}
+		if d > nukesWindow {
+			d = nukesWindow
+		}
+		exp = expiry{At: n.Time.AsTime().Add(d), Topic: topicNukes, What: n.Word}
+	case eventMutelinks:
+		m := e.Data.(*pb.Mutelinks)
+		if m.Status == "off" {
+			return
+		}
+		d, err := parseModDuration(m.Duration)
+		if err != nil {
+			log.Warnf("Couldn't schedule the mutelinks expiry: %s", err)
+			return
+		}
+		exp = expiry{At: m.Time.AsTime().Add(d), Topic: topicMutelinks, What: m.Status}
+	case eventPhrase:
+		p := e.Data.(*pb.Phrase)
+		if p.Duration == "" {
+			return
+		}
+		d, err := parseModDuration(p.Duration)
+		if err != nil {
+			// permanent phrases don't expire
+			return
+		}
+		exp = expiry{At: p.Time.AsTime().Add(d), Topic: topicPhrases, What: p.Phrase}
+	default:
+		return
+	}
+
+	expiries.schedule(exp)
+}
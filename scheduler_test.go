@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock only moves when advance is called, every timer it
+// creates is reported on created so a test can wait for the loop
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	created chan time.Duration
+}
+
+type fakeTimer struct {
+	at      time.Time
+	c       chan time.Time
+	stopped bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		created: make(chan time.Duration, 16),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) timer {
+	c.mu.Lock()
+	t := &fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+	c.created <- d
+	return &fakeTimerHandle{clock: c, t: t}
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.stopped {
+			continue
+		}
+		if !t.at.After(c.now) {
+			t.c <- c.now
+			continue
+		}
+		pending = append(pending, t)
+	}
+	c.timers = pending
+}
+
+type fakeTimerHandle struct {
+	clock *fakeClock
+	t     *fakeTimer
+}
+
+func (h *fakeTimerHandle) C() <-chan time.Time {
+	return h.t.c
+}
+
+func (h *fakeTimerHandle) Stop() bool {
+	h.clock.mu.Lock()
+	defer h.clock.mu.Unlock()
+	wasActive := !h.t.stopped
+	h.t.stopped = true
+	return wasActive
+}
+
+// waitForTimer waits for the loop to start a timer for d, a wake-up left
+// over from an earlier schedule call can make it restart the same timer
+// once, so any other timers started before that are skipped
+func waitForTimer(t *testing.T, c *fakeClock, d time.Duration) {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case got := <-c.created:
+			if got == d {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("the scheduler never started a timer for %s", d)
+		}
+	}
+}
+
+func waitForExpiry(t *testing.T, fired chan expiry) expiry {
+	t.Helper()
+	select {
+	case e := <-fired:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no expiry fired")
+		return expiry{}
+	}
+}
+
+func startScheduler(t *testing.T, c *fakeClock) (*scheduler, chan expiry) {
+	t.Helper()
+	fired := make(chan expiry, 16)
+	s := newScheduler(c, func(e expiry, now time.Time) {
+		fired <- e
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return s, fired
+}
+
+func TestSchedulerFiresInOrder(t *testing.T) {
+	c := newFakeClock()
+	start := c.Now()
+	s, fired := startScheduler(t, c)
+
+	s.schedule(expiry{At: start.Add(time.Second * 3), What: "third"})
+	s.schedule(expiry{At: start.Add(time.Second), What: "first"})
+	s.schedule(expiry{At: start.Add(time.Second * 2), What: "second"})
+
+	waitForTimer(t, c, time.Second)
+	c.advance(time.Second * 5)
+
+	for _, expected := range []string{"first", "second", "third"} {
+		if e := waitForExpiry(t, fired); e.What != expected {
+			t.Errorf("got the %q expiry, expected %q", e.What, expected)
+		}
+	}
+}
+
+func TestSchedulerWakesForEarlierExpiry(t *testing.T) {
+	c := newFakeClock()
+	start := c.Now()
+	s, fired := startScheduler(t, c)
+
+	s.schedule(expiry{At: start.Add(time.Minute), What: "late"})
+	waitForTimer(t, c, time.Minute)
+
+	s.schedule(expiry{At: start.Add(time.Second), What: "early"})
+	waitForTimer(t, c, time.Second)
+
+	c.advance(time.Second)
+	if e := waitForExpiry(t, fired); e.What != "early" {
+		t.Errorf("got the %q expiry, expected %q", e.What, "early")
+	}
+	// the loop goes back to waiting for the late one
+	waitForTimer(t, c, time.Minute-time.Second)
+	select {
+	case e := <-fired:
+		t.Errorf("the %q expiry fired early", e.What)
+	default:
+	}
+}
+
+func TestParseModDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"":    defaultModDuration,
+		"10m": time.Minute * 10,
+		"1H":  time.Hour,
+		"2d":  time.Hour * 48,
+	}
+	for input, expected := range valid {
+		d, err := parseModDuration(input)
+		if err != nil {
+			t.Errorf("parseModDuration(%q) failed: %s", input, err)
+			continue
+		}
+		if d != expected {
+			t.Errorf("parseModDuration(%q) = %s, expected %s", input, d, expected)
+		}
+	}
+
+	for _, input := range []string{"m", "10", "10x", "-5m", "abc", "1.5h"} {
+		if d, err := parseModDuration(input); err == nil {
+			t.Errorf("parseModDuration(%q) = %s, expected an error", input, d)
+		}
+	}
+}
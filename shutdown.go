@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+// serverCtx is cancelled as soon as the shutdown starts, the streaming
+// handlers watch it so draining doesn't wait on clients that never leave
+var serverCtx, stopServing = context.WithCancel(context.Background())
+
+// loopsCtx is only cancelled once the servers are drained,
+// so the background loops keep working for in-flight requests
+var loopsCtx, stopLoops = context.WithCancel(context.Background())
+var loops sync.WaitGroup
+
+// background runs a loop that gets stopped and waited for on shutdown
+func background(loop func(ctx context.Context)) {
+	loops.Add(1)
+	go func() {
+		defer loops.Done()
+		loop(loopsCtx)
+	}()
+}
+
+// waitWithin waits for wait to return, giving up once ctx is done
+func waitWithin(ctx context.Context, wait func()) bool {
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// waitForSignal blocks until SIGINT or SIGTERM,
+// a second one skips the graceful shutdown
+func waitForSignal() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	sig := <-signals
+	log.Infof("Got %s, shutting down gracefully", sig)
+
+	go func() {
+		sig := <-signals
+		log.Fatalf("Got %s again, exiting right away", sig)
+	}()
+}
+
+// shutdown fails /readyz for cfg.ShutdownDrainDelay, then stops taking
+// connections, drains HTTP and gRPC, stops the background loops and
+// closes the stores, all within cfg.ShutdownTimeout
+func shutdown(api *fiber.App) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	stopServing()
+	if cfg.ShutdownDrainDelay > 0 {
+		log.Infof("Waiting %s for the load balancer to notice the shutdown", cfg.ShutdownDrainDelay)
+		time.Sleep(cfg.ShutdownDrainDelay)
+	}
+
+	if err := api.ShutdownWithContext(ctx); err != nil {
+		log.Errorf("Couldn't drain the HTTP server: %s", err)
+	}
+
+	if !waitWithin(ctx, grpcServer.GracefulStop) {
+		log.Warnf("The gRPC server didn't drain in time, closing the remaining connections")
+		grpcServer.Stop()
+	}
+
+	stopLoops()
+	if !waitWithin(ctx, loops.Wait) {
+		log.Warnf("Some background loops didn't stop in time")
+	}
+
+	closeStores()
+
+	log.Infof("Shut down successfully")
+}
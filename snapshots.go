@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+// snapshotPollInterval is how often the SQLite files get checked for
+// changes, the scrapers writing them only run every few minutes anyway
+const snapshotPollInterval = time.Second * 5
+
+// snapshot keeps a whole SQLite dataset in memory and reloads it once the
+// file changes, version is the file's mtime in milliseconds so every
+// replica reading the same file ends up with the same version
+type snapshot[T any] struct {
+	name  string
+	store string
+	path  *string
+	load  func() (T, error)
+
+	mu      sync.RWMutex
+	data    T
+	version int64
+	loaded  bool
+}
+
+// dataset is what the poller needs from a snapshot regardless of its type
+type dataset interface {
+	datasetName() string
+	datasetVersion() int64
+	refresh()
+}
+
+var featuresSnapshot = &snapshot[map[string]string]{name: "features", store: storeFeatures, path: &cfg.SQLite.Features, load: queryFeatures}
+var ytvodsSnapshot = &snapshot[[]ytvod]{name: "ytvods", store: storeYTVods, path: &cfg.SQLite.YTVods, load: queryYTvods}
+var rumbleSnapshot = &snapshot[[]rumblevod]{name: "rumblevods", store: storeRumble, path: &cfg.SQLite.Rumble, load: func() ([]rumblevod, error) {
+	return queryRumbleVods(rumbledb)
+}}
+var omnimirrorSnapshot = &snapshot[[]rumblevod]{name: "omnimirror", store: storeOmnimirror, path: &cfg.SQLite.Omnimirror, load: func() ([]rumblevod, error) {
+	return queryRumbleVods(omnimirrordb)
+}}
+var lwodSnapshot = &snapshot[lwodDataset]{name: "lwod", store: storeLWOD, path: &cfg.SQLite.LWOD, load: queryLWOD}
+
+var datasets = []dataset{featuresSnapshot, ytvodsSnapshot, rumbleSnapshot, omnimirrorSnapshot, lwodSnapshot}
+
+func (s *snapshot[T]) datasetName() string {
+	return s.name
+}
+
+func (s *snapshot[T]) datasetVersion() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+// get returns the current data, which is shared and must not be modified
+func (s *snapshot[T]) get() (T, int64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data, s.version, s.loaded
+}
+
+// modified is the validator source for the conditional middleware,
+// so the ETag always matches the snapshot that's actually served
+func (s *snapshot[T]) modified() (time.Time, error) {
+	version := s.datasetVersion()
+	if version == 0 {
+		return time.Time{}, nil
+	}
+	return time.UnixMilli(version), nil
+}
+
+// refresh reloads the dataset if the file changed since the last load,
+// a failed reload keeps serving the old data and is retried on the next poll
+func (s *snapshot[T]) refresh() {
+	if !storeAvailable(s.store) {
+		return
+	}
+	// stat before loading, a write that lands mid-load
+	// then shows up as a newer mtime on the next poll
+	modTime, err := fileModified(*s.path)()
+	if err != nil {
+		log.Errorf("Couldn't stat the %s dataset: %s", s.name, err)
+		return
+	}
+	version := modTime.UnixMilli()
+	if version == s.datasetVersion() {
+		return
+	}
+
+	data, err := observe(s.store, s.name, s.load)
+	if err != nil {
+		log.Errorf("Couldn't load the %s dataset: %s", s.name, err)
+		return
+	}
+
+	s.mu.Lock()
+	old := s.version
+	s.data = data
+	s.version = version
+	s.loaded = true
+	s.mu.Unlock()
+
+	log.Infof("Reloaded the %s dataset: %+v -> %+v", s.name, old, version)
+}
+
+func refreshDatasets() {
+	for _, d := range datasets {
+		d.refresh()
+	}
+}
+
+func watchDatasets(ctx context.Context) {
+	ticker := time.NewTicker(snapshotPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refreshDatasets()
+		}
+	}
+}
+
+// snapshotData hands the snapshot to a handler and tells
+// the client which version of the dataset it's getting
+func snapshotData[T any](c *fiber.Ctx, s *snapshot[T]) (T, bool) {
+	data, version, ok := s.get()
+	if !ok {
+		log.FiberErrorf(c, nil, "The %s dataset hasn't been loaded", s.name)
+		return data, false
+	}
+	c.Set("X-Dataset-Version", strconv.FormatInt(version, 10))
+	return data, true
+}
+
+func getDatasets(c *fiber.Ctx) error {
+	versions := make(map[string]int64)
+	for _, d := range datasets {
+		versions[d.datasetName()] = d.datasetVersion()
+	}
+	return c.JSON(versions)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	_ "time/tzdata"
+
+	"github.com/go-redis/redis/v8"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+const (
+	stampPhrases       = "phrases"
+	stampPhraseRemoval = "phraseRemoval"
+	stampNukes         = "nukes"
+	stampMutelinks     = "mutelinks"
+	stampEmbeds        = "embeds"
+)
+
+const stampsKey = "vyneer-api:stamps"
+
+// advanceStampScript only ever moves a persisted stamp forward, so replicas
+// sharing the hash can't overwrite a newer stamp with an older one
+var advanceStampScript = redis.NewScript(`
+local current = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+if tonumber(ARGV[2]) > current then
+	redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// stampStore holds the millisecond timestamps clients use to tell whether
+// their data is stale, every stamp only ever moves forward
+type stampStore struct {
+	values map[string]*atomic.Int64
+}
+
+var stamps = newStampStore()
+
+func newStampStore() *stampStore {
+	s := &stampStore{
+		values: make(map[string]*atomic.Int64),
+	}
+	for _, name := range []string{stampPhrases, stampPhraseRemoval, stampNukes, stampMutelinks, stampEmbeds} {
+		s.values[name] = &atomic.Int64{}
+	}
+	return s
+}
+
+func (s *stampStore) get(name string) int64 {
+	return s.values[name].Load()
+}
+
+func (s *stampStore) getter(name string) func() int64 {
+	return func() int64 {
+		return s.get(name)
+	}
+}
+
+// phrases is the stamp clients see for the phrases,
+// it moves both when phrases get added and removed
+func (s *stampStore) phrases() int64 {
+	phrases := s.get(stampPhrases)
+	removal := s.get(stampPhraseRemoval)
+	if phrases >= removal {
+		return phrases
+	}
+	return removal
+}
+
+// advance sets the stamp to value if it's newer than the current one,
+// returning the previous value and whether the stamp moved
+func (s *stampStore) advance(name string, value int64) (int64, bool) {
+	v := s.values[name]
+	for {
+		old := v.Load()
+		if value <= old {
+			return old, false
+		}
+		if v.CompareAndSwap(old, value) {
+			if cfg.PersistStamps {
+				err := advanceStampScript.Run(context.Background(), rdb, []string{stampsKey}, name, value).Err()
+				if err != nil {
+					log.Errorf("Couldn't persist the %s stamp: %s", name, err)
+				}
+			}
+			return old, true
+		}
+	}
+}
+
+// update advances the stamp and lets the subscribers of the
+// matching topic know, reason goes into the log line
+func (s *stampStore) update(name string, value int64, reason string) bool {
+	old, ok := s.advance(name, value)
+	if !ok {
+		log.Infof("%s, keeping the %s stamp at %+v since %+v is older", reason, name, old, value)
+		return false
+	}
+	log.Infof("%s, updating the %s stamp: %+v -> %+v", reason, name, old, value)
+
+	switch name {
+	case stampPhrases, stampPhraseRemoval:
+		notifyStamp(topicPhrases, s.phrases())
+	default:
+		notifyStamp(name, value)
+	}
+	return true
+}
+
+// load restores the persisted stamps, so the removal
+// stamp in particular survives a restart
+func (s *stampStore) load() error {
+	raw, err := rdb.HGetAll(context.Background(), stampsKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for name, value := range raw {
+		if _, ok := s.values[name]; !ok {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Errorf("Couldn't parse the persisted %s stamp: %s", name, err)
+			continue
+		}
+		s.advance(name, parsed)
+	}
+
+	log.Infof("Loaded the persisted stamps: phrases %+v, nukes %+v, mutelinks %+v, embeds %+v", s.phrases(), s.get(stampNukes), s.get(stampMutelinks), s.get(stampEmbeds))
+	return nil
+}
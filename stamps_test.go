@@ -0,0 +1,81 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+)
+
+func TestStampAdvanceConcurrently(t *testing.T) {
+	s := newStampStore()
+
+	const goroutines = 64
+	const perGoroutine = 200
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			// interleave the values so the goroutines keep racing each other
+			for i := 0; i < perGoroutine; i++ {
+				s.advance(stampNukes, int64(i*goroutines+g+1))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if got, expected := s.get(stampNukes), int64(goroutines*perGoroutine); got != expected {
+		t.Errorf("the stamp ended at %d, expected the max value %d", got, expected)
+	}
+}
+
+func TestStampUpdateRefusesOlderValue(t *testing.T) {
+	s := newStampStore()
+
+	if !s.update(stampMutelinks, 2000, "test") {
+		t.Fatal("the first update didn't move the stamp")
+	}
+	if s.update(stampMutelinks, 1000, "test") {
+		t.Error("an older value moved the stamp")
+	}
+	if s.update(stampMutelinks, 2000, "test") {
+		t.Error("the same value moved the stamp")
+	}
+	if got := s.get(stampMutelinks); got != 2000 {
+		t.Errorf("the stamp is %d, expected 2000", got)
+	}
+}
+
+func TestStampPhrasesCombinesRemoval(t *testing.T) {
+	s := newStampStore()
+
+	s.advance(stampPhrases, 1000)
+	if got := s.phrases(); got != 1000 {
+		t.Errorf("phrases() = %d, expected the phrase stamp 1000", got)
+	}
+
+	s.advance(stampPhraseRemoval, 3000)
+	if got := s.phrases(); got != 3000 {
+		t.Errorf("phrases() = %d, expected the newer removal stamp 3000", got)
+	}
+
+	s.advance(stampPhrases, 5000)
+	if got := s.phrases(); got != 5000 {
+		t.Errorf("phrases() = %d, expected the newer phrase stamp 5000", got)
+	}
+}
+
+func TestStampQueriedSkipsFailedQueries(t *testing.T) {
+	if !stampQueried(stampNukes, nil) {
+		t.Error("a successful query got skipped")
+	}
+	if !stampQueried(stampNukes, pgx.ErrNoRows) || !stampQueried(stampEmbeds, sql.ErrNoRows) {
+		t.Error("an empty table got skipped, it has to reconcile to a 0 stamp")
+	}
+	if stampQueried(stampNukes, errors.New("connection refused")) {
+		t.Error("a failed query got reconciled as an empty table")
+	}
+}
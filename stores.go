@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	storePostgres   = "postgres"
+	storePhrases    = "phrases"
+	storeNukes      = "nukes"
+	storeMutelinks  = "mutelinks"
+	storeLogs       = "logs"
+	storeRedis      = "redis"
+	storeFeatures   = "features"
+	storeLWOD       = "lwod"
+	storeYTVods     = "ytvods"
+	storeRumble     = "rumble"
+	storeOmnimirror = "omnimirror"
+	storeEmbeds     = "embeds"
+)
+
+// storeTables are the tables a store needs, a configured
+// store missing any of them stops the server at startup
+var storeTables = map[string][]string{
+	storeFeatures:   {"dggfeat"},
+	storeLWOD:       {"lwod", "lwodUrl"},
+	storeYTVods:     {"ytvods"},
+	storeRumble:     {"rumble"},
+	storeOmnimirror: {"rumble"},
+	storeEmbeds:     {"embeds"},
+}
+
+// available is only written while the databases get loaded, so it's
+// safe to read without a lock once the server is running
+var available = make(map[string]bool)
+
+func storeAvailable(store string) bool {
+	return available[store]
+}
+
+// storeDisabled tells whether a path turns the store off, env vars
+// can't be set to an empty string so "off" does the same thing
+func storeDisabled(path string) bool {
+	return path == "" || path == "off"
+}
+
+func missingTables(store string, exists func(table string) (bool, error)) error {
+	missing := []string{}
+	for _, table := range storeTables[store] {
+		ok, err := exists(table)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			missing = append(missing, table)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing the %s table(s)", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// openSQLite opens an existing database, unlike a plain sql.Open it never
+// creates an empty file, a missing file leaves the store unavailable
+func openSQLite(store string, path string) *sql.DB {
+	if storeDisabled(path) {
+		log.Infof("The %s database isn't configured, its endpoints are disabled", store)
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		log.Warnf("Couldn't find the %s database, its endpoints are disabled: %s", store, err)
+		return nil
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=rw")
+	if err != nil {
+		log.Fatalf("Error opening the %s database: %s", store, err)
+	}
+
+	err = missingTables(store, func(table string) (bool, error) {
+		var count int
+		err := db.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = $1 COLLATE NOCASE", table).Scan(&count)
+		return count > 0, err
+	})
+	if err != nil {
+		log.Fatalf("The %s database at %s isn't usable: %s", store, path, err)
+	}
+
+	available[store] = true
+	return db
+}
+
+// postgresTables splits Postgres into a store per table, some deployments
+// only have the logs and nukes side so a missing table only turns off
+// the endpoints that read from it
+var postgresTables = map[string]string{
+	storePhrases:   "phrases",
+	storeNukes:     "nukes",
+	storeMutelinks: "mutelinks",
+	storeLogs:      "logs",
+}
+
+func checkPostgresTables() {
+	for store, table := range postgresTables {
+		var exists bool
+		err := pg.QueryRow(context.Background(), "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists)
+		if err != nil {
+			log.Fatalf("The Postgres database isn't usable: %s", err)
+		}
+		if !exists {
+			log.Warnf("The Postgres database has no %s table, its endpoints are disabled", table)
+			continue
+		}
+		available[store] = true
+	}
+}
+
+// closeStores closes every store that got opened
+func closeStores() {
+	for _, db := range []*sql.DB{featdb, lwoddb, ytvoddb, rumbledb, omnimirrordb, embeddb} {
+		if db != nil {
+			db.Close()
+		}
+	}
+	if pg != nil {
+		pg.Close()
+	}
+	if rdb != nil {
+		rdb.Close()
+	}
+}
+
+func unavailableMessage(store string) string {
+	return fmt.Sprintf("The %s store isn't configured on this server", store)
+}
+
+// requires answers with a 503 when any of the stores the route reads from
+// isn't configured, instead of failing with a 500 at query time
+func requires(stores ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, store := range stores {
+			if !storeAvailable(store) {
+				return c.Status(503).SendString(unavailableMessage(store))
+			}
+		}
+		return c.Next()
+	}
+}
+
+// requireStore is the gRPC counterpart of requires
+func requireStore(store string) error {
+	if !storeAvailable(store) {
+		return status.Error(codes.Unavailable, unavailableMessage(store))
+	}
+	return nil
+}
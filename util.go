@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"time"
 	_ "time/tzdata"
 
@@ -55,6 +56,27 @@ type phrase struct {
 	Type     string    `json:"type"`
 }
 
+type phraseConflict struct {
+	Index    int    `json:"index"`
+	Existing phrase `json:"existing"`
+	Incoming phrase `json:"incoming"`
+}
+
+type phraseInvalid struct {
+	Index  int    `json:"index"`
+	Phrase string `json:"phrase"`
+	Error  string `json:"error"`
+}
+
+type phraseImportReport struct {
+	DryRun    bool             `json:"dryRun"`
+	Imported  int              `json:"imported"`
+	Replaced  int              `json:"replaced"`
+	Unchanged int              `json:"unchanged"`
+	Conflicts []phraseConflict `json:"conflicts"`
+	Invalid   []phraseInvalid  `json:"invalid"`
+}
+
 type lwodUrl struct {
 	ID string
 }
@@ -84,6 +106,16 @@ type lwod struct {
 	Topic   string  `json:"topic"`
 }
 
+// lwodDataset is the whole lwod table, indexed
+// the ways the /lwod endpoint gets queried
+type lwodDataset struct {
+	all     []lwod
+	twitch  map[string][]lwodTwitch
+	youtube map[string][]lwodYT
+}
+
+const logLineStringLayout = "2006-01-02T15:04:05.000Z"
+
 type logLineString struct {
 	Time     string `json:"time"`
 	Username string `json:"username"`
@@ -111,10 +143,31 @@ type nuke struct {
 	Victims  string    `json:"victims"`
 }
 
+type mutelinksStatus struct {
+	Time     time.Time `json:"time"`
+	Status   string    `json:"status"`
+	Duration string    `json:"duration"`
+	User     string    `json:"user"`
+}
+
 type msgCount struct {
 	Count int `json:"count"`
 }
 
+var phraseCSVHeader = []string{"time", "username", "phrase", "duration", "type"}
+
+func isPhraseCSVHeader(record []string) bool {
+	if len(record) != len(phraseCSVHeader) {
+		return false
+	}
+	for i, column := range record {
+		if strings.TrimSpace(column) != phraseCSVHeader[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func MinMax(array []time.Time) (time.Time, time.Time) {
 	var max time.Time = array[0]
 	var min time.Time = array[0]
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+const webhooksKey = "vyneer-api:webhooks"
+const webhooksQueueKey = "vyneer-api:webhooks:queue"
+const webhooksDeadLetterKey = "vyneer-api:webhooks:deadletter"
+const webhooksDeadLetterMax = 1000
+const webhookAttempts = 5
+const webhookWorkers = 8
+
+// webhooksRefresh is how long the registered webhooks are cached, changes
+// made through another replica show up here within it
+const webhooksRefresh = time.Second * 10
+
+var webhookClient = &http.Client{Timeout: time.Second * 10}
+var webhookBackoff = time.Second
+
+type webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Types     []string  `json:"types"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type webhookJob struct {
+	hook      webhook
+	eventType string
+	body      []byte
+}
+
+type webhookDeadLetter struct {
+	WebhookID string          `json:"webhookId"`
+	URL       string          `json:"url"`
+	Event     json.RawMessage `json:"event"`
+	Error     string          `json:"error"`
+	Attempts  int             `json:"attempts"`
+	FailedAt  time.Time       `json:"failedAt"`
+}
+
+func (w webhook) wants(eventType string) bool {
+	if len(w.Types) == 0 {
+		return true
+	}
+	for _, t := range w.Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func webhooks() ([]webhook, error) {
+	hooks := []webhook{}
+
+	raw, err := rdb.HGetAll(context.Background(), webhooksKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for id, value := range raw {
+		w := webhook{}
+		if err := json.Unmarshal([]byte(value), &w); err != nil {
+			log.Errorf("Couldn't unmarshal the %s webhook: %s", id, err)
+			continue
+		}
+		hooks = append(hooks, w)
+	}
+
+	return hooks, nil
+}
+
+// hookCache saves the dispatcher a redis round-trip per event
+var hookCache struct {
+	sync.Mutex
+	hooks  []webhook
+	loaded time.Time
+}
+
+func cachedWebhooks() ([]webhook, error) {
+	hookCache.Lock()
+	defer hookCache.Unlock()
+
+	if time.Since(hookCache.loaded) < webhooksRefresh {
+		return hookCache.hooks, nil
+	}
+	hooks, err := webhooks()
+	if err != nil {
+		return nil, err
+	}
+	hookCache.hooks = hooks
+	hookCache.loaded = time.Now()
+	return hooks, nil
+}
+
+func invalidateWebhooks() {
+	hookCache.Lock()
+	hookCache.loaded = time.Time{}
+	hookCache.Unlock()
+}
+
+// signWebhook signs the timestamp and the body together, so a captured
+// delivery can't be replayed later with a different timestamp
+func signWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliverWebhook(ctx context.Context, w webhook, eventType string, body []byte) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vyneer-api-webhooks")
+	req.Header.Set("X-Webhook-ID", w.ID)
+	req.Header.Set("X-Webhook-Event", eventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", signWebhook(w.Secret, timestamp, body))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// dispatchWebhook retries a delivery with a growing backoff, once ctx is
+// done the delivery is given up on and dead-lettered right away so it's
+// stored before the shutdown closes redis
+func dispatchWebhook(ctx context.Context, job webhookJob) {
+	var err error
+	backoff := webhookBackoff
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		err = deliverWebhook(ctx, job.hook, job.eventType, job.body)
+		if err == nil {
+			return
+		}
+		if ctx.Err() == nil {
+			log.Warnf("Webhook %s delivery attempt %d/%d failed: %s", job.hook.ID, attempt, webhookAttempts, err)
+		}
+		if ctx.Err() == nil && attempt < webhookAttempts {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+			}
+			backoff *= 2
+		}
+		if ctx.Err() != nil {
+			log.Warnf("Webhook %s delivery was interrupted by the shutdown, moving it to the dead-letter list", job.hook.ID)
+			deadLetterWebhook(job, attempt, fmt.Errorf("the server shut down before the delivery succeeded: %w", err))
+			return
+		}
+	}
+
+	log.Errorf("Webhook %s delivery failed %d times, moving it to the dead-letter list", job.hook.ID, webhookAttempts)
+	deadLetterWebhook(job, webhookAttempts, err)
+}
+
+func deadLetterWebhook(job webhookJob, attempts int, err error) {
+	deadLetter, _ := json.Marshal(webhookDeadLetter{
+		WebhookID: job.hook.ID,
+		URL:       job.hook.URL,
+		Event:     job.body,
+		Error:     err.Error(),
+		Attempts:  attempts,
+		FailedAt:  time.Now(),
+	})
+	if err := storeDeadLetter(deadLetter); err != nil {
+		log.Errorf("Couldn't store the dead-lettered webhook %s delivery: %s", job.hook.ID, err)
+	}
+}
+
+var storeDeadLetter = func(deadLetter []byte) error {
+	pipe := rdb.TxPipeline()
+	pipe.LPush(context.Background(), webhooksDeadLetterKey, deadLetter)
+	pipe.LTrim(context.Background(), webhooksDeadLetterKey, 0, webhooksDeadLetterMax-1)
+	_, err := pipe.Exec(context.Background())
+	return err
+}
+
+// queueWebhooks hands an event this replica ingested to the dispatchers
+// through a redis list, unlike the in-memory brokers it never drops events
+// when the deliveries fall behind and the backlog survives a restart,
+// the fanned out copies are queued by the replica that got the gRPC call
+func queueWebhooks(e event) {
+	if rdb == nil || !live.Load().cfg.Toggles.Webhooks {
+		return
+	}
+
+	hooks, err := cachedWebhooks()
+	if err != nil {
+		log.Errorf("Couldn't get the webhooks from redis: %s", err)
+		return
+	}
+	wanted := false
+	for _, w := range hooks {
+		wanted = wanted || w.wants(e.Type)
+	}
+	if !wanted {
+		return
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf("Couldn't marshal a %s event for the webhooks: %s", e.Type, err)
+		return
+	}
+	if err := rdb.LPush(context.Background(), webhooksQueueKey, body).Err(); err != nil {
+		log.Errorf("Couldn't queue a %s event for the webhooks: %s", e.Type, err)
+	}
+}
+
+// webhookDispatcher pops the queued events and hands the deliveries to a
+// fixed pool of workers, it only pops the next event once a worker is free
+// so a slow endpoint backs the queue up in redis instead of in memory,
+// it returns once every worker is done so closeStores never runs under them
+func webhookDispatcher(ctx context.Context) {
+	jobs := make(chan webhookJob)
+	var workers sync.WaitGroup
+	for i := 0; i < webhookWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for job := range jobs {
+				dispatchWebhook(ctx, job)
+			}
+		}()
+	}
+	defer func() {
+		close(jobs)
+		workers.Wait()
+	}()
+
+	for ctx.Err() == nil {
+		result, err := rdb.BRPop(ctx, time.Second, webhooksQueueKey).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) && ctx.Err() == nil {
+				log.Errorf("Couldn't pop a webhook event from redis: %s", err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Second):
+				}
+			}
+			continue
+		}
+
+		body := []byte(result[1])
+		e := eventJSON{}
+		if err := json.Unmarshal(body, &e); err != nil {
+			log.Errorf("Couldn't unmarshal a queued webhook event: %s", err)
+			continue
+		}
+		hooks, err := cachedWebhooks()
+		if err != nil {
+			log.Errorf("Couldn't get the webhooks from redis: %s", err)
+			continue
+		}
+
+		for _, w := range hooks {
+			if !w.wants(e.Type) {
+				continue
+			}
+			job := webhookJob{hook: w, eventType: e.Type, body: body}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				deadLetterWebhook(job, 0, errors.New("the server shut down before the delivery started"))
+			}
+		}
+	}
+}
+
+func getWebhooks(c *fiber.Ctx) error {
+	hooks, err := webhooks()
+	if err != nil {
+		log.FiberErrorf(c, err, "redis query error")
+		return c.SendStatus(500)
+	}
+
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+
+	return c.JSON(hooks)
+}
+
+func postWebhook(c *fiber.Ctx) error {
+	w := webhook{}
+	if err := json.Unmarshal(c.Body(), &w); err != nil {
+		return c.Status(400).SendString(fmt.Sprintf("Couldn't parse the JSON body: %s", err))
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return c.Status(400).SendString("The url needs to be an absolute http or https URL")
+	}
+	for _, t := range w.Types {
+		if _, err := newEventData(t); err != nil {
+			return c.Status(400).SendString(fmt.Sprintf("Unknown event type: %s", t))
+		}
+	}
+	if w.Types == nil {
+		w.Types = []string{}
+	}
+
+	w.ID = randomHex(8)
+	if w.Secret == "" {
+		w.Secret = randomHex(32)
+	}
+	w.CreatedAt = time.Now()
+
+	value, _ := json.Marshal(w)
+	if err := rdb.HSet(context.Background(), webhooksKey, w.ID, value).Err(); err != nil {
+		log.FiberErrorf(c, err, "redis query error")
+		return c.SendStatus(500)
+	}
+
+	invalidateWebhooks()
+	log.Infof("Registered the %s webhook for %s", w.ID, w.URL)
+
+	// the secret is only ever shown here
+	return c.Status(201).JSON(w)
+}
+
+func deleteWebhook(c *fiber.Ctx) error {
+	deleted, err := rdb.HDel(context.Background(), webhooksKey, c.Params("id")).Result()
+	if err != nil {
+		log.FiberErrorf(c, err, "redis query error")
+		return c.SendStatus(500)
+	}
+	if deleted == 0 {
+		return c.Status(404).SendString("Webhook not found")
+	}
+
+	invalidateWebhooks()
+	log.Infof("Removed the %s webhook", c.Params("id"))
+
+	return c.SendStatus(204)
+}
+
+func getWebhooksDeadLetter(c *fiber.Ctx) error {
+	deadLetters := []webhookDeadLetter{}
+
+	raw, err := rdb.LRange(context.Background(), webhooksDeadLetterKey, 0, -1).Result()
+	if err != nil {
+		log.FiberErrorf(c, err, "redis query error")
+		return c.SendStatus(500)
+	}
+
+	for _, value := range raw {
+		p := webhookDeadLetter{}
+		if err := json.Unmarshal([]byte(value), &p); err != nil {
+			continue
+		}
+		deadLetters = append(deadLetters, p)
+	}
+
+	return c.JSON(deadLetters)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookStandIn answers with the given status codes in order,
+// repeating the last one, and checks every delivery it gets
+func webhookStandIn(t *testing.T, secret string, codes ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1))
+
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(r.Header.Get("X-Webhook-Timestamp") + "." + string(body)))
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		if r.Header.Get("X-Webhook-Signature") != expected {
+			t.Errorf("delivery %d has the signature %q, expected %q", call, r.Header.Get("X-Webhook-Signature"), expected)
+		}
+		if r.Header.Get("X-Webhook-Event") != eventNuke {
+			t.Errorf("delivery %d has the event type %q", call, r.Header.Get("X-Webhook-Event"))
+		}
+
+		if call > len(codes) {
+			call = len(codes)
+		}
+		w.WriteHeader(codes[call-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// captureDeadLetters swaps the redis dead-letter list for a slice
+func captureDeadLetters(t *testing.T) *[]webhookDeadLetter {
+	t.Helper()
+	captured := []webhookDeadLetter{}
+	previousStore, previousBackoff := storeDeadLetter, webhookBackoff
+	storeDeadLetter = func(deadLetter []byte) error {
+		d := webhookDeadLetter{}
+		if err := json.Unmarshal(deadLetter, &d); err != nil {
+			t.Errorf("couldn't unmarshal the dead letter: %s", err)
+		}
+		captured = append(captured, d)
+		return nil
+	}
+	webhookBackoff = time.Millisecond
+	t.Cleanup(func() {
+		storeDeadLetter, webhookBackoff = previousStore, previousBackoff
+	})
+	return &captured
+}
+
+func testJob(url string, secret string) webhookJob {
+	return webhookJob{
+		hook:      webhook{ID: "test", URL: url, Secret: secret},
+		eventType: eventNuke,
+		body:      []byte(`{"type":"nuke","time":1,"data":{}}`),
+	}
+}
+
+func TestWebhookSignedDelivery(t *testing.T) {
+	deadLetters := captureDeadLetters(t)
+	server, calls := webhookStandIn(t, "secret", http.StatusOK)
+
+	dispatchWebhook(context.Background(), testJob(server.URL, "secret"))
+
+	if atomic.LoadInt32(calls) != 1 {
+		t.Errorf("got %d deliveries, expected 1", atomic.LoadInt32(calls))
+	}
+	if len(*deadLetters) != 0 {
+		t.Errorf("a successful delivery got dead-lettered")
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	deadLetters := captureDeadLetters(t)
+	server, calls := webhookStandIn(t, "secret", http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent)
+
+	dispatchWebhook(context.Background(), testJob(server.URL, "secret"))
+
+	if atomic.LoadInt32(calls) != 3 {
+		t.Errorf("got %d deliveries, expected 3", atomic.LoadInt32(calls))
+	}
+	if len(*deadLetters) != 0 {
+		t.Errorf("a delivery that succeeded on a retry got dead-lettered")
+	}
+}
+
+func TestWebhookDeadLetter(t *testing.T) {
+	deadLetters := captureDeadLetters(t)
+	server, calls := webhookStandIn(t, "secret", http.StatusServiceUnavailable)
+
+	job := testJob(server.URL, "secret")
+	dispatchWebhook(context.Background(), job)
+
+	if atomic.LoadInt32(calls) != webhookAttempts {
+		t.Errorf("got %d deliveries, expected %d", atomic.LoadInt32(calls), webhookAttempts)
+	}
+	if len(*deadLetters) != 1 {
+		t.Fatalf("got %d dead letters, expected 1", len(*deadLetters))
+	}
+	d := (*deadLetters)[0]
+	if d.WebhookID != job.hook.ID || d.URL != server.URL || d.Attempts != webhookAttempts {
+		t.Errorf("unexpected dead letter %+v", d)
+	}
+	if string(d.Event) != string(job.body) {
+		t.Errorf("the dead letter has the event %s, expected %s", d.Event, job.body)
+	}
+	if !strings.Contains(d.Error, "503") {
+		t.Errorf("the dead letter error %q doesn't mention the status code", d.Error)
+	}
+}
+
+func TestWebhookShutdownDeadLetters(t *testing.T) {
+	deadLetters := captureDeadLetters(t)
+	// the backoff outlasts the test, only the shutdown can end the wait
+	webhookBackoff = time.Hour
+	server, calls := webhookStandIn(t, "secret", http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond*50, cancel)
+
+	done := make(chan struct{})
+	go func() {
+		dispatchWebhook(ctx, testJob(server.URL, "secret"))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("the delivery kept retrying after the shutdown")
+	}
+
+	if atomic.LoadInt32(calls) > 1 {
+		t.Errorf("got %d deliveries, expected at most 1", atomic.LoadInt32(calls))
+	}
+	if len(*deadLetters) != 1 {
+		t.Fatalf("got %d dead letters, expected 1", len(*deadLetters))
+	}
+	if d := (*deadLetters)[0]; d.Attempts != 1 || !strings.Contains(d.Error, "shut down") {
+		t.Errorf("unexpected dead letter %+v", d)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/gofiber/websocket/v2"
+	log "github.com/vyneer/vyneer-api/logger"
+)
+
+const (
+	topicNukes     = "nukes"
+	topicPhrases   = "phrases"
+	topicMutelinks = "mutelinks"
+	topicEmbeds    = "embeds"
+)
+
+// stampUpdates carries a stamp-only event (Data is nil) every time one of
+// the topic stamps moves, no matter whether it came from gRPC or from
+// doubleCheckStamps
+var stampUpdates = newBroker[event](16)
+
+type wsRequest struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+type wsMessage struct {
+	Topic     string      `json:"topic"`
+	UpdatedAt int64       `json:"updatedAt"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+func notifyStamp(topic string, stamp int64) {
+	stampUpdates.publish(event{Type: topic, Time: stamp})
+}
+
+func topicStamp(topic string) int64 {
+	switch topic {
+	case topicNukes:
+		return stamps.get(stampNukes)
+	case topicPhrases:
+		return stamps.phrases()
+	case topicMutelinks:
+		return stamps.get(stampMutelinks)
+	case topicEmbeds:
+		return stamps.get(stampEmbeds)
+	}
+	return 0
+}
+
+func topicData(topic string) (interface{}, error) {
+	store := ""
+	switch topic {
+	case topicNukes:
+		store = storeNukes
+	case topicPhrases:
+		store = storePhrases
+	case topicMutelinks:
+		store = storeMutelinks
+	case topicEmbeds:
+		store = storeEmbeds
+	}
+	if store != "" && !storeAvailable(store) {
+		return nil, errors.New(unavailableMessage(store))
+	}
+
+	switch topic {
+	case topicNukes:
+		return nukes()
+	case topicPhrases:
+		return phrases("")
+	case topicMutelinks:
+		return mutelinks()
+	case topicEmbeds:
+		return lastEmbeds()
+	}
+	return nil, nil
+}
+
+func topicMessage(topic string, stamp int64) wsMessage {
+	data, err := topicData(topic)
+	if err != nil {
+		log.Errorf("WebSocket %s topic error: %s", topic, err)
+		return wsMessage{
+			Topic:     topic,
+			UpdatedAt: stamp,
+			Error:     "Couldn't get the data for this topic",
+		}
+	}
+	return wsMessage{
+		Topic:     topic,
+		UpdatedAt: stamp,
+		Data:      data,
+	}
+}
+
+func wsSubscriptions(c *websocket.Conn) {
+	updates := stampUpdates.subscribe()
+	defer stampUpdates.unsubscribe(updates)
+
+	requests := make(chan wsRequest)
+	done := make(chan struct{})
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		defer close(done)
+		for {
+			req := wsRequest{}
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			select {
+			case requests <- req:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(time.Second * 30)
+	defer ping.Stop()
+
+	subscribed := make(map[string]bool)
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case <-serverCtx.Done():
+			c.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "the server is shutting down"), time.Now().Add(time.Second))
+			return
+		case req := <-requests:
+			for _, topic := range req.Topics {
+				switch topic {
+				case topicNukes, topicPhrases, topicMutelinks, topicEmbeds:
+				default:
+					err = c.WriteJSON(wsMessage{Topic: topic, Error: "Unknown topic"})
+					continue
+				}
+				switch req.Action {
+				case "subscribe":
+					subscribed[topic] = true
+					err = c.WriteJSON(topicMessage(topic, topicStamp(topic)))
+				case "unsubscribe":
+					delete(subscribed, topic)
+				default:
+					err = c.WriteJSON(wsMessage{Topic: topic, Error: "Unknown action, expected subscribe or unsubscribe"})
+				}
+			}
+		case e := <-updates:
+			if subscribed[e.Type] {
+				err = c.WriteJSON(topicMessage(e.Type, e.Time))
+			}
+		case <-ping.C:
+			err = c.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second*10))
+		}
+		if err != nil {
+			return
+		}
+	}
+}